@@ -1,18 +1,19 @@
 package animation
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
 	"github.com/faiface/pixel"
-	"github.com/svera/quarter"
+	"github.com/svera/quarter/bound"
 )
 
 // Returned errors
 const (
 	ErrorVersionNotSupported = "Version \"%s\" not supported"
 	ErrorNoAnims             = "File must have at least one animation declared, none found"
+	ErrorDurationsNotValid   = "Number of durations of animation \"%s\" does not match its number of frames"
+	ErrorShapesNotValid      = "Number of frames of shapes \"%s\" of animation \"%s\" does not match its number of frames"
 )
 
 // AnimFile defines the structure of a disk file containing information about animations
@@ -23,9 +24,27 @@ type AnimFile struct {
 		Frames   int
 		Cycle    string
 		Duration float64
-		YOffset  float64 `json:"y_offset"`
-		Width    float64
-		Height   float64
+		// Durations holds how many seconds every frame is shown, overriding Duration if present
+		Durations []float64
+		// Loops is the number of times circular and ping pong animations are played. 0 means forever
+		Loops int
+		// Hold defines whether an animation stays on its last frame when it is over, which is the default,
+		// or goes back to its first one
+		Hold *bool
+		// Events holds named events emitted when the frames they are attached to are shown
+		Events []struct {
+			Frame int
+			Name  string
+		}
+		// Anchor is the point of the frames, relative to their bottom left corner, placed at the animation position.
+		// Frames are placed by their center if not present
+		Anchor *pixel.Vec
+		// Shapes holds named sets of bound shapes, relative to the anchor point, for every frame.
+		// A set with a single entry is used for all frames
+		Shapes  map[string][][]bound.ShapeData
+		YOffset float64 `json:"y_offset"`
+		Width   float64
+		Height  float64
 	}
 }
 
@@ -35,99 +54,124 @@ const (
 	CircularReverse
 	Circular
 	Single
+	PingPong
+	PingPongReverse
 )
 
 // Returned errors
 const (
 	ErrorAnimationDoesNotExist = "Animation '%s' does not exist"
+	ErrorFrameDoesNotExist     = "Frame %d of animation '%s' does not exist"
 )
 
 // To convert string values used in sprite definition file to integer values used internally
-var animationCycle = map[string]int{"single_reverse": -2, "circular_reverse": -1, "circular": 0, "single": 1}
+var animationCycle = map[string]int{"single_reverse": -2, "circular_reverse": -1, "circular": 0, "single": 1, "ping_pong": 2, "ping_pong_reverse": 3}
 
 type sequence struct {
-	frames       []*pixel.Sprite
-	timePerFrame float64
-	cycle        int
+	frames    []*pixel.Sprite
+	durations []float64
+	cycle     int
+	loops     int
+	hold      bool
+	events    map[int][]string
+	anchor    *pixel.Vec
+	// shapes holds the bound shapes of every frame, by name
+	shapes map[string][][]bound.Shaper
+}
+
+// maxLoops returns how many times the sequence is played, 0 meaning forever
+func (s *sequence) maxLoops() int {
+	if s.cycle == Single || s.cycle == SingleReverse {
+		return 1
+	}
+	return s.loops
+}
+
+func (s *sequence) reverse() bool {
+	return s.cycle == SingleReverse || s.cycle == CircularReverse || s.cycle == PingPongReverse
 }
 
-// Animation implements an animated sprite
+// Animation implements an animated sprite, keeping its own playback state
 type Animation struct {
-	anims              map[string]*sequence
+	library            *Library
 	currentAnimID      string
 	currentFrameNumber int
 	elapsed            float64
 	Position           pixel.Vec
 	Dir                float64
-	over               bool
+	// Rotation is the angle in radians the sprite is rotated around its anchor point
+	Rotation float64
+	// Scale multiplies the size of the sprite around its anchor point
+	Scale pixel.Vec
+	// FlipV draws the sprite upside down
+	FlipV bool
+	// Mask is the color mask the sprite is drawn with
+	Mask pixel.RGBA
+	over bool
+	// backwards is true when a ping pong animation is going back to the frame it started from
+	backwards bool
+	// loop is the number of times the current animation has been completely played
+	loop int
+	// started is false until the first frame of the current animation is shown
+	started    bool
+	events     []Event
+	onFrame    func(Event)
+	onLoop     func(Event)
+	onComplete func(Event)
+	// picture, if set, overrides the library picture for this instance only
+	picture pixel.Picture
+	// sprites holds the frames of every animation taken from picture, cloned the first time they are drawn
+	sprites map[string][]*pixel.Sprite
+	// shapes caches the transformed shapes of the current frame by name, while shapesKey does not change
+	shapes    map[string][]bound.Shape
+	shapesKey shapesKey
+}
+
+// shapesKey identifies the frame and placement cached shapes were transformed for
+type shapesKey struct {
+	anim      string
+	frame     int
+	placement pixel.Matrix
 }
 
-// NewAnimation returns a new Sprite instance to be drawn at position x, y
+// NewAnimation returns a new Sprite instance to be drawn at position x, y, with its own library
 func NewAnimation(pos pixel.Vec, numberAnims int) *Animation {
-	return &Animation{
-		anims:    make(map[string]*sequence, numberAnims),
-		Position: pos,
-		Dir:      1,
-	}
+	return NewLibrary(numberAnims).New(pos)
 }
 
+// Deserialize loads an animations file and returns a new Animation instance which uses its frames.
+// Use DeserializeLibrary instead when more than one instance of the same animations is needed
 func Deserialize(r io.Reader, pos pixel.Vec) (*Animation, error) {
-	data := &AnimFile{}
-	err := json.NewDecoder(r).Decode(data)
-
+	lib, err := DeserializeLibrary(r)
 	if err != nil {
 		return nil, err
 	}
-
-	anim := NewAnimation(pos, len(data.Anims))
-
-	if data.Version != "1" {
-		return anim, fmt.Errorf(ErrorVersionNotSupported, data.Version)
-	}
-
-	pic, err := quarter.LoadPicture(data.Sheet)
-	if err != nil {
-		return anim, err
-	}
-
-	if len(data.Anims) == 0 {
-		return nil, fmt.Errorf(ErrorNoAnims)
-	}
-
-	for i, an := range data.Anims {
-		anim.AddAnim(i, pic, an.YOffset, an.Width, an.Height, an.Frames, an.Duration, an.Cycle)
-	}
-	return anim, nil
+	return lib.New(pos), nil
 }
 
-// AddAnim adds a new animation to the Sprite, identified with ID,
-// whose frames are taken from pic from left to right, starting from X = 0
-// duration defines how many seconds should it take for the animation to complete a cycle
+// AddAnim adds a new animation to the Sprite library. See Library.AddAnim
 func (a *Animation) AddAnim(idx string, pic pixel.Picture, yOffset, width, height float64, numberFrames int, duration float64, cycle string) {
-	a.anims[idx] = &sequence{
-		timePerFrame: duration / float64(numberFrames),
-	}
-	a.anims[idx].cycle = animationCycle[cycle]
-	var x float64
-	for i := 0; i < numberFrames; i++ {
-		x = width * float64(i)
-		a.anims[idx].frames = append(a.anims[idx].frames, pixel.NewSprite(pic, pixel.R(x, yOffset, x+width, yOffset+height)))
-	}
+	a.library.AddAnim(idx, pic, yOffset, width, height, numberFrames, duration, cycle)
+}
+
+// Library returns the library the animation takes its frames from
+func (a *Animation) Library() *Library {
+	return a.library
 }
 
 // SetCurrentAnim defines which animation to play
 func (a *Animation) SetCurrentAnim(ID string) error {
-	if _, ok := a.anims[ID]; !ok {
+	if _, ok := a.library.anims[ID]; !ok {
 		return fmt.Errorf(ErrorAnimationDoesNotExist, ID)
 	}
 	if ID != a.currentAnimID {
 		a.currentAnimID = ID
-		a.currentFrameNumber = 0
-		if a.anims[a.currentAnimID].cycle == SingleReverse || a.anims[a.currentAnimID].cycle == CircularReverse {
-			a.currentFrameNumber = a.lastFrame()
-		}
+		a.currentFrameNumber = a.firstFrame()
 		a.elapsed = 0
 		a.over = false
+		a.backwards = false
+		a.loop = 0
+		a.started = false
 	}
 	return nil
 }
@@ -137,51 +181,211 @@ func (a *Animation) CurrentAnim() string {
 	return a.currentAnimID
 }
 
-// Draw draws Sprite current frame on target, and updates the former if needed
-func (a *Animation) Draw(target pixel.Target, dt float64) {
-	m := pixel.IM.ScaledXY(pixel.ZV, pixel.V(a.Dir, 1)).Moved(a.Position)
-	a.anims[a.currentAnimID].frames[a.currentFrameNumber].Draw(target, m)
-	a.elapsed += dt
-	if idx := a.nextFrameIndex(); idx != a.currentFrameNumber {
-		a.elapsed = 0
-		a.currentFrameNumber = idx
+// TimeScale multiplies the time passed to all animations on update, so values lower than 1
+// produce slow motion and greater than 1 fast forward. It is combined with the TimeScale of every library
+var TimeScale = 1.0
+
+// Draw draws Sprite current frame on target
+func (a *Animation) Draw(target pixel.Target) {
+	a.frame().DrawColorMask(target, a.Matrix(), a.Mask)
+}
+
+// frame returns the sprite of the current frame, taken from the instance picture if it has one
+func (a *Animation) frame() *pixel.Sprite {
+	frames := a.current().frames
+	if a.picture == nil {
+		return frames[a.currentFrameNumber]
 	}
+	sprites, ok := a.sprites[a.currentAnimID]
+	if !ok {
+		sprites = make([]*pixel.Sprite, len(frames))
+		for i, frame := range frames {
+			sprites[i] = pixel.NewSprite(a.picture, frame.Frame())
+		}
+		a.sprites[a.currentAnimID] = sprites
+	}
+	return sprites[a.currentFrameNumber]
 }
 
-func (a *Animation) nextFrameIndex() int {
-	if a.elapsed <= a.anims[a.currentAnimID].timePerFrame {
-		return a.currentFrameNumber
+// Matrix returns the transformation used to draw the current frame, which places its anchor point at Position
+// and then flips, scales and rotates the sprite around it
+func (a *Animation) Matrix() pixel.Matrix {
+	return pixel.IM.Moved(a.anchor().Scaled(-1)).Chained(a.placement())
+}
+
+// TransformShape returns a copy of s, defined relative to the anchor point,
+// placed, flipped, scaled and rotated the same way the current frame is drawn
+func (a *Animation) TransformShape(s bound.Shaper) bound.Shape {
+	return bound.Transform(s, a.placement())
+}
+
+// Shapes returns the bound shapes of the current frame identified with name,
+// aligned to the animation position and transformed the same way the frame is drawn.
+// Returned shapes are reused until the frame or its placement change, so they must not be modified
+func (a *Animation) Shapes(name string) []bound.Shape {
+	frames, ok := a.current().shapes[name]
+	if !ok {
+		return nil
+	}
+	key := shapesKey{anim: a.currentAnimID, frame: a.currentFrameNumber, placement: a.placement()}
+	if a.shapes == nil {
+		a.shapes = make(map[string][]bound.Shape, len(a.current().shapes))
+	}
+	if key != a.shapesKey {
+		for n := range a.shapes {
+			delete(a.shapes, n)
+		}
+		a.shapesKey = key
 	}
-	if a.anims[a.currentAnimID].cycle == CircularReverse {
-		if a.currentFrameNumber == 0 {
-			return len(a.anims[a.currentAnimID].frames) - 1
+	if shapes, ok := a.shapes[name]; ok {
+		return shapes
+	}
+	shapes := make([]bound.Shape, len(frames[a.currentFrameNumber]))
+	for i, s := range frames[a.currentFrameNumber] {
+		shapes[i] = bound.Transform(s, key.placement)
+	}
+	a.shapes[name] = shapes
+	return shapes
+}
+
+func (a *Animation) placement() pixel.Matrix {
+	flipV := 1.0
+	if a.FlipV {
+		flipV = -1
+	}
+	return pixel.IM.
+		ScaledXY(pixel.ZV, pixel.V(a.Dir*a.Scale.X, flipV*a.Scale.Y)).
+		Rotated(pixel.ZV, a.Rotation).
+		Moved(a.Position)
+}
+
+// anchor returns the anchor point of the current frame relative to its center
+func (a *Animation) anchor() pixel.Vec {
+	seq := a.current()
+	if seq.anchor == nil {
+		return pixel.ZV
+	}
+	return seq.anchor.Sub(seq.frames[a.currentFrameNumber].Frame().Size().Scaled(0.5))
+}
+
+// Update advances the animation dt seconds, scaled by TimeScale and its library one, moving as many frames as needed
+func (a *Animation) Update(dt float64) {
+	a.events = a.events[:0]
+	if !a.started {
+		a.started = true
+		a.emitFrameEvents()
+	}
+	a.elapsed += dt * TimeScale * a.library.TimeScale
+	for !a.over {
+		duration := a.current().durations[a.currentFrameNumber]
+		if a.elapsed < duration {
+			return
+		}
+		a.elapsed -= duration
+		a.currentFrameNumber = a.nextFrameIndex()
+		if !a.over {
+			a.emitFrameEvents()
+		}
+		// Frames without duration are skipped one per update, to avoid looping forever
+		if duration <= 0 {
+			return
 		}
-		return a.currentFrameNumber - 1
+	}
+}
+
+func (a *Animation) nextFrameIndex() int {
+	if a.current().cycle == PingPong || a.current().cycle == PingPongReverse {
+		return a.nextPingPongFrameIndex()
 	}
 
-	if a.anims[a.currentAnimID].cycle == SingleReverse {
-		if a.currentFrameNumber > 0 {
-			return a.currentFrameNumber - 1
+	next := a.currentFrameNumber + 1
+	if a.current().reverse() {
+		next = a.currentFrameNumber - 1
+	}
+	if next < 0 || next > a.lastFrame() {
+		return a.completeLoop()
+	}
+	return next
+}
+
+func (a *Animation) nextPingPongFrameIndex() int {
+	first, last, step := 0, a.lastFrame(), 1
+	if a.current().reverse() {
+		first, last, step = last, first, -1
+	}
+	if first == last || (a.backwards && a.currentFrameNumber == first) {
+		a.backwards = false
+		idx := a.completeLoop()
+		if a.over || first == last {
+			return idx
 		}
-		return a.currentFrameNumber
+		return a.currentFrameNumber + step
+	}
+	if a.currentFrameNumber == last {
+		a.backwards = true
 	}
+	if a.backwards {
+		return a.currentFrameNumber - step
+	}
+	return a.currentFrameNumber + step
+}
 
-	if a.isLastFrame(a.currentFrameNumber) && a.anims[a.currentAnimID].cycle == Circular {
-		return 0
+// completeLoop is called when all frames of the current animation have been shown, and returns
+// the frame index the animation must continue with
+func (a *Animation) completeLoop() int {
+	a.loop++
+	if max := a.current().maxLoops(); max > 0 && a.loop >= max {
+		a.over = true
+		a.emit(Event{Type: EventComplete, Anim: a.currentAnimID, Frame: a.currentFrameNumber}, a.onComplete)
+		if a.current().hold {
+			return a.currentFrameNumber
+		}
+		return a.firstFrame()
 	}
-	if !a.isLastFrame(a.currentFrameNumber) {
-		return a.currentFrameNumber + 1
+	a.emit(Event{Type: EventLoop, Anim: a.currentAnimID, Frame: a.currentFrameNumber}, a.onLoop)
+	return a.firstFrame()
+}
+
+func (a *Animation) firstFrame() int {
+	if a.current().reverse() {
+		return a.lastFrame()
 	}
-	a.over = true
-	return a.currentFrameNumber
+	return 0
+}
+
+func (a *Animation) current() *sequence {
+	return a.library.anims[a.currentAnimID]
 }
 
 func (a *Animation) lastFrame() int {
-	return len(a.anims[a.currentAnimID].frames) - 1
+	return len(a.current().frames) - 1
 }
 
 func (a *Animation) isLastFrame(number int) bool {
-	return len(a.anims[a.currentAnimID].frames)-1 == number
+	return len(a.current().frames)-1 == number
+}
+
+// Picture returns the picture frames are taken from
+func (a *Animation) Picture() pixel.Picture {
+	if a.picture != nil {
+		return a.picture
+	}
+	return a.library.Picture()
+}
+
+// SetPicture makes the frames of this instance to be taken from pic, keeping their bounds,
+// while other animations created from the same library keep their own picture.
+// This is useful to draw a recolored version of a sprite. Use Library.SetPicture to change all of them
+func (a *Animation) SetPicture(pic pixel.Picture) {
+	a.picture = pic
+	if a.sprites == nil {
+		a.sprites = make(map[string][]*pixel.Sprite, len(a.library.anims))
+	}
+	for _, sprites := range a.sprites {
+		for _, sprite := range sprites {
+			sprite.Set(pic, sprite.Frame())
+		}
+	}
 }
 
 // CurrentFrameNumber returns the frame number that it is drawn
@@ -1,11 +1,14 @@
 package animation_test
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/faiface/pixel"
 	"github.com/svera/quarter/animation"
+	"github.com/svera/quarter/bound"
 )
 
 func TestSetCurrentAnim(t *testing.T) {
@@ -26,3 +29,306 @@ func TestSetCurrentAnim(t *testing.T) {
 		})
 	}
 }
+
+func TestLibrary(t *testing.T) {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 40, 10))
+	lib := animation.NewLibrary(2)
+	lib.AddAnim("idle", pic, 0, 10, 10, 4, 1, "circular")
+	lib.AddAnim("running", pic, 0, 10, 10, 2, 1, "circular")
+
+	first := lib.New(pixel.V(0, 0))
+	second := lib.New(pixel.V(20, 0))
+
+	t.Run("Instances keep their own playback state", func(t *testing.T) {
+		first.SetCurrentAnim("idle")
+		second.SetCurrentAnim("running")
+		if first.CurrentAnim() != "idle" || second.CurrentAnim() != "running" {
+			t.Errorf("Expected animations \"idle\" and \"running\", got \"%s\" and \"%s\"", first.CurrentAnim(), second.CurrentAnim())
+		}
+	})
+
+	t.Run("Instances share library frames", func(t *testing.T) {
+		if first.Library() != lib || second.Library() != lib {
+			t.Errorf("Instances must use the library they were created from")
+		}
+	})
+
+	t.Run("Instances keep their own picture", func(t *testing.T) {
+		recolored := pixel.MakePictureData(pixel.R(0, 0, 40, 10))
+		first.SetPicture(recolored)
+		// Batches panic when drawing sprites taken from a different picture
+		first.Draw(pixel.NewBatch(&pixel.TrianglesData{}, recolored))
+		second.Draw(pixel.NewBatch(&pixel.TrianglesData{}, pic))
+		if first.Picture() != recolored || second.Picture() != pic || lib.Picture() != pic {
+			t.Errorf("Expected picture to be changed only for the first instance")
+		}
+	})
+
+	t.Run("Frames without a duration each are not added", func(t *testing.T) {
+		expectedError := fmt.Sprintf(animation.ErrorDurationsNotValid, "jumping")
+		err := lib.AddFrames("jumping", pic, []pixel.Rect{pixel.R(0, 0, 10, 10), pixel.R(10, 0, 20, 10)}, []float64{0.5}, animation.Single)
+		if err == nil || err.Error() != expectedError {
+			t.Errorf("Expected error \"%s\", got \"%v\"", expectedError, err)
+		}
+		if err := first.SetCurrentAnim("jumping"); err == nil {
+			t.Errorf("Expected animation not to be added")
+		}
+	})
+
+	t.Run("Library picture is changed for instances without their own picture", func(t *testing.T) {
+		recolored := pixel.MakePictureData(pixel.R(0, 0, 40, 10))
+		lib.SetPicture(recolored)
+		if second.Picture() != recolored || first.Picture() == recolored {
+			t.Errorf("Expected picture to be changed only for the second instance")
+		}
+	})
+}
+
+func TestFrameAdvance(t *testing.T) {
+	uniform := []float64{0.25, 0.25, 0.25}
+	steps := []float64{0.25, 0.25, 0.25, 0.25, 0.25, 0.25}
+
+	var testValues = []struct {
+		testName       string
+		cycle          int
+		durations      []float64
+		loops          int
+		hold           bool
+		dts            []float64
+		expectedFrames []int
+		expectedOver   bool
+	}{
+		{"Circular animation goes back to first frame", animation.Circular, uniform, 0, true, steps[:4], []int{1, 2, 0, 1}, false},
+		{"Circular reverse animation goes back to last frame", animation.CircularReverse, uniform, 0, true, steps[:4], []int{1, 0, 2, 1}, false},
+		{"Single animation stays on last frame", animation.Single, uniform, 0, true, steps[:3], []int{1, 2, 2}, true},
+		{"Single reverse animation stays on first frame", animation.SingleReverse, uniform, 0, true, steps[:3], []int{1, 0, 0}, true},
+		{"Single animation without hold goes back to first frame", animation.Single, uniform, 0, false, steps[:4], []int{1, 2, 0, 0}, true},
+		{"Ping pong animation goes back and forth", animation.PingPong, uniform, 0, true, steps[:5], []int{1, 2, 1, 0, 1}, false},
+		{"Ping pong animation stops after loops are played", animation.PingPong, uniform, 1, true, steps[:5], []int{1, 2, 1, 0, 0}, true},
+		{"Ping pong reverse animation goes back and forth from last frame", animation.PingPongReverse, uniform, 0, true, steps[:5], []int{1, 0, 1, 2, 1}, false},
+		{"Ping pong reverse animation stops after loops are played", animation.PingPongReverse, uniform, 1, true, steps[:5], []int{1, 0, 1, 2, 2}, true},
+		{"Circular animation holds last frame after loops are played", animation.Circular, uniform, 2, true, steps, []int{1, 2, 0, 1, 2, 2}, true},
+		{"Circular animation goes back to first frame after loops are played", animation.Circular, uniform, 1, false, steps[:4], []int{1, 2, 0, 0}, true},
+		{"Frames are shown for their own duration", animation.Circular, []float64{0.25, 0.75, 0.25}, 0, true, []float64{0.25, 0.5, 0.25}, []int{1, 1, 2}, false},
+		{"Frame is not advanced before its duration is reached", animation.Circular, uniform, 0, true, []float64{0.125, 0.125}, []int{0, 1}, false},
+		{"Time left over is carried to the next frame", animation.Circular, uniform, 0, true, []float64{0.375, 0.375, 0.375, 0.375}, []int{1, 0, 1, 0}, false},
+		{"Many frames are advanced if needed", animation.Circular, uniform, 0, true, []float64{0.5, 0.5}, []int{2, 1}, false},
+		{"Single animation is over if needed", animation.Single, uniform, 0, true, []float64{1}, []int{2}, true},
+	}
+	pic := pixel.MakePictureData(pixel.R(0, 0, 30, 10))
+	frames := []pixel.Rect{pixel.R(0, 0, 10, 10), pixel.R(10, 0, 20, 10), pixel.R(20, 0, 30, 10)}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			lib := animation.NewLibrary(1)
+			lib.AddFrames("test", pic, frames, tt.durations, tt.cycle)
+			lib.SetLoops("test", tt.loops, tt.hold)
+			an := lib.New(pixel.ZV)
+			an.SetCurrentAnim("test")
+			for i, dt := range tt.dts {
+				an.Update(dt)
+				if an.CurrentFrameNumber() != tt.expectedFrames[i] {
+					t.Errorf("Step %d: expected frame %d, got %d", i, tt.expectedFrames[i], an.CurrentFrameNumber())
+				}
+			}
+			if an.IsOver() != tt.expectedOver {
+				t.Errorf("Expected over to be %t, got %t", tt.expectedOver, an.IsOver())
+			}
+		})
+	}
+}
+
+func TestDeserializeLibrary(t *testing.T) {
+	path, cleanup := writeSheet(t)
+	defer cleanup()
+
+	t.Run("Number of durations must match number of frames", func(t *testing.T) {
+		data := fmt.Sprintf(`{"version": "1", "sheet": "%s", "anims": {"idle": {"frames": 3, "durations": [0.1, 0.2], "width": 10, "height": 20}}}`, path)
+		_, err := animation.DeserializeLibrary(bytes.NewReader([]byte(data)))
+		if err == nil || err.Error() != fmt.Sprintf(animation.ErrorDurationsNotValid, "idle") {
+			t.Errorf("Expected durations not valid error, got %v", err)
+		}
+	})
+
+	t.Run("Number of frames is taken from durations if not declared", func(t *testing.T) {
+		data := fmt.Sprintf(`{"version": "1", "sheet": "%s", "anims": {"idle": {"durations": [0.1, 0.2], "cycle": "ping_pong", "loops": 2, "hold": false, "width": 10, "height": 20}}}`, path)
+		lib, err := animation.DeserializeLibrary(bytes.NewReader([]byte(data)))
+		if err != nil {
+			t.Fatalf("Valid animation data is not loaded: %s", err)
+		}
+		an := lib.New(pixel.ZV)
+		an.SetCurrentAnim("idle")
+		an.Update(0.15)
+		if an.CurrentFrameNumber() != 1 {
+			t.Errorf("Expected frame 1, got %d", an.CurrentFrameNumber())
+		}
+	})
+
+	t.Run("Shapes are loaded", func(t *testing.T) {
+		data := fmt.Sprintf(`{"version": "1", "sheet": "%s", "anims": {"idle": {"frames": 2, "duration": 1, "width": 10, "height": 20,
+			"shapes": {"body": [[{"type": "box", "values": {"min": {"x": -2, "y": -5}, "max": {"x": 2, "y": 5}}}]]}}}}`, path)
+		lib, err := animation.DeserializeLibrary(bytes.NewReader([]byte(data)))
+		if err != nil {
+			t.Fatalf("Valid animation data is not loaded: %s", err)
+		}
+		an := lib.New(pixel.ZV)
+		an.SetCurrentAnim("idle")
+		an.Update(0.5)
+		if shapes := an.Shapes("body"); len(shapes) != 1 || shapes[0].(*bound.Box).Rect != pixel.R(-2, -5, 2, 5) {
+			t.Errorf("Shapes declared once must be used for all frames, got %v", shapes)
+		}
+	})
+
+	t.Run("Number of frames of shapes must match number of frames", func(t *testing.T) {
+		data := fmt.Sprintf(`{"version": "1", "sheet": "%s", "anims": {"idle": {"frames": 3, "duration": 1, "width": 10, "height": 20,
+			"shapes": {"body": [[], []]}}}}`, path)
+		_, err := animation.DeserializeLibrary(bytes.NewReader([]byte(data)))
+		if err == nil || err.Error() != fmt.Sprintf(animation.ErrorShapesNotValid, "body", "idle") {
+			t.Errorf("Expected shapes not valid error, got %v", err)
+		}
+	})
+
+	t.Run("Anchor points are loaded", func(t *testing.T) {
+		data := fmt.Sprintf(`{"version": "1", "sheet": "%s", "anims": {"idle": {"frames": 1, "duration": 1, "anchor": {"x": 5, "y": 0}, "width": 10, "height": 20}}}`, path)
+		lib, err := animation.DeserializeLibrary(bytes.NewReader([]byte(data)))
+		if err != nil {
+			t.Fatalf("Valid animation data is not loaded: %s", err)
+		}
+		an := lib.New(pixel.ZV)
+		an.SetCurrentAnim("idle")
+		if center := an.Matrix().Project(pixel.ZV); center != pixel.V(0, 10) {
+			t.Errorf("Expected frame center at %v, got %v", pixel.V(0, 10), center)
+		}
+	})
+}
+
+func TestShapes(t *testing.T) {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 20, 10))
+	lib := animation.NewLibrary(1)
+	lib.AddFrames("attack", pic, []pixel.Rect{pixel.R(0, 0, 10, 10), pixel.R(10, 0, 20, 10)}, []float64{0.25, 0.25}, animation.Single)
+	lib.AddShape("attack", "hurtbox", 0, bound.NewBox(pixel.V(-2, -5), pixel.V(2, 5)))
+	lib.AddShape("attack", "hurtbox", 1, bound.NewBox(pixel.V(-2, -5), pixel.V(2, 5)))
+	lib.AddShape("attack", "hurtbox", 1, bound.NewBox(pixel.V(-2, 5), pixel.V(2, 7)))
+	lib.AddShape("attack", "hitbox", 1, bound.NewBox(pixel.V(2, 0), pixel.V(5, 2)))
+
+	if err := lib.AddShape("attack", "hitbox", 2, bound.NewBox(pixel.ZV, pixel.ZV)); err == nil || err.Error() != fmt.Sprintf(animation.ErrorFrameDoesNotExist, 2, "attack") {
+		t.Errorf("Expected frame does not exist error, got %v", err)
+	}
+
+	an := lib.New(pixel.V(100, 100))
+	an.SetCurrentAnim("attack")
+	an.Dir = -1
+
+	var testValues = []struct {
+		testName       string
+		name           string
+		dt             float64
+		expectedShapes []pixel.Rect
+	}{
+		{"Shapes are aligned to position", "hurtbox", 0, []pixel.Rect{pixel.R(98, 95, 102, 105)}},
+		{"Frames without shapes return none", "hitbox", 0, []pixel.Rect{}},
+		{"Unknown shapes return none", "shield", 0, []pixel.Rect{}},
+		{"Frames can have many shapes", "hurtbox", 0.25, []pixel.Rect{pixel.R(98, 95, 102, 105), pixel.R(98, 105, 102, 107)}},
+		{"Shapes are flipped with direction", "hitbox", 0, []pixel.Rect{pixel.R(95, 100, 98, 102)}},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			an.Update(tt.dt)
+			shapes := an.Shapes(tt.name)
+			if len(shapes) != len(tt.expectedShapes) {
+				t.Fatalf("Expected %d shapes, got %d", len(tt.expectedShapes), len(shapes))
+			}
+			for i, s := range shapes {
+				if s.(*bound.Box).Rect != tt.expectedShapes[i] {
+					t.Errorf("Expected shape %v, got %v", tt.expectedShapes[i], s.(*bound.Box).Rect)
+				}
+			}
+		})
+	}
+}
+
+func TestShapesCache(t *testing.T) {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 10, 10))
+	lib := animation.NewLibrary(1)
+	lib.AddFrames("idle", pic, []pixel.Rect{pixel.R(0, 0, 10, 10)}, []float64{0.25}, animation.Circular)
+	lib.AddShape("idle", "body", 0, bound.NewBox(pixel.V(-2, -5), pixel.V(2, 5)))
+	an := lib.New(pixel.ZV)
+	an.SetCurrentAnim("idle")
+
+	an.Shapes("body")
+	if allocs := testing.AllocsPerRun(10, func() { an.Shapes("body") }); allocs != 0 {
+		t.Errorf("Expected shapes of the same frame and placement to be reused, got %f allocations", allocs)
+	}
+	an.Position = pixel.V(10, 0)
+	if shapes := an.Shapes("body"); shapes[0].(*bound.Box).Rect != pixel.R(8, -5, 12, 5) {
+		t.Errorf("Expected shapes to be transformed again when placement changes, got %v", shapes[0].(*bound.Box).Rect)
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 10, 20))
+	frames := []pixel.Rect{pic.Bounds()}
+	lib := animation.NewLibrary(3)
+	lib.AddFrames("idle", pic, frames, []float64{1}, animation.Single)
+	lib.AddFrames("walk", pic, frames, []float64{1}, animation.Single)
+	lib.SetAnchor("walk", pixel.V(5, 0))
+	lib.AddFrames("push", pic, frames, []float64{1}, animation.Single)
+	lib.SetAnchor("push", pixel.V(0, 0))
+
+	var testValues = []struct {
+		testName       string
+		ID             string
+		set            func(an *animation.Animation)
+		expectedCenter pixel.Vec
+		expectedShape  pixel.Rect
+	}{
+		{"Frames are placed by their center by default", "idle", func(an *animation.Animation) {}, pixel.V(100, 100), pixel.R(98, 100, 102, 110)},
+		{"Anchor point is placed at position", "walk", func(an *animation.Animation) {}, pixel.V(100, 110), pixel.R(98, 100, 102, 110)},
+		{"Horizontal flip is done around anchor point", "push", func(an *animation.Animation) { an.Dir = -1 }, pixel.V(95, 110), pixel.R(98, 100, 102, 110)},
+		{"Vertical flip is done around anchor point", "walk", func(an *animation.Animation) { an.FlipV = true }, pixel.V(100, 90), pixel.R(98, 90, 102, 100)},
+		{"Scale is done around anchor point", "walk", func(an *animation.Animation) { an.Scale = pixel.V(2, 2) }, pixel.V(100, 120), pixel.R(96, 100, 104, 120)},
+		{"Rotation is done around anchor point", "walk", func(an *animation.Animation) { an.Rotation = math.Pi / 2 }, pixel.V(90, 100), pixel.R(90, 98, 100, 102)},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			an := lib.New(pixel.V(100, 100))
+			an.SetCurrentAnim(tt.ID)
+			tt.set(an)
+			if center := an.Matrix().Project(pixel.ZV); center.Sub(tt.expectedCenter).Len() > 1e-9 {
+				t.Errorf("Expected frame center at %v, got %v", tt.expectedCenter, center)
+			}
+			shape := an.TransformShape(bound.NewBox(pixel.V(-2, 0), pixel.V(2, 10))).(*bound.Box)
+			if shape.Min.Sub(tt.expectedShape.Min).Len() > 1e-9 || shape.Max.Sub(tt.expectedShape.Max).Len() > 1e-9 {
+				t.Errorf("Expected shape %v, got %v", tt.expectedShape, shape.Rect)
+			}
+		})
+	}
+}
+
+func TestTimeScale(t *testing.T) {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 20, 10))
+	lib := animation.NewLibrary(1)
+	lib.AddFrames("idle", pic, []pixel.Rect{pixel.R(0, 0, 10, 10), pixel.R(10, 0, 20, 10)}, []float64{0.5, 0.5}, animation.Circular)
+	an := lib.New(pixel.ZV)
+	an.SetCurrentAnim("idle")
+
+	animation.TimeScale = 0.5
+	defer func() { animation.TimeScale = 1 }()
+	an.Update(0.5)
+	if an.CurrentFrameNumber() != 0 {
+		t.Errorf("Expected frame 0 in slow motion, got %d", an.CurrentFrameNumber())
+	}
+	an.Update(0.5)
+	if an.CurrentFrameNumber() != 1 {
+		t.Errorf("Expected frame 1 in slow motion, got %d", an.CurrentFrameNumber())
+	}
+
+	lib.TimeScale = 0.5
+	an.Update(1)
+	if an.CurrentFrameNumber() != 1 {
+		t.Errorf("Expected library time scale to be combined with the global one, got frame %d", an.CurrentFrameNumber())
+	}
+	an.Update(1)
+	if an.CurrentFrameNumber() != 0 {
+		t.Errorf("Expected frame 0 with both time scales, got %d", an.CurrentFrameNumber())
+	}
+}
@@ -0,0 +1,208 @@
+package animation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter"
+	"github.com/svera/quarter/bound"
+)
+
+// Returned errors
+const (
+	ErrorDirectionNotSupported = "Direction \"%s\" of tag \"%s\" not supported"
+	ErrorFramesNotValid        = "Frames data is not valid"
+	ErrorRepeatNotValid        = "Repeat \"%s\" of tag \"%s\" is not valid"
+)
+
+// To convert tag directions used by Aseprite to cycle values used internally
+var asepriteDirection = map[string]int{"forward": Circular, "reverse": CircularReverse, "pingpong": PingPong, "pingpong_reverse": PingPongReverse}
+
+// AsepriteFile defines the structure of the JSON file exported by Aseprite, both in hash and array formats
+type AsepriteFile struct {
+	Frames json.RawMessage
+	Meta   struct {
+		Image string
+		Size  struct {
+			W float64
+			H float64
+		}
+		FrameTags []struct {
+			Name      string
+			From      int
+			To        int
+			Direction string
+			// Repeat is the number of times the tag is played, stored as a string. Empty or 0 means forever
+			Repeat string
+		}
+		Slices []struct {
+			Name string
+			Keys []struct {
+				Frame  int
+				Bounds asepriteRect
+			}
+		}
+	}
+}
+
+type asepriteRect struct {
+	X float64
+	Y float64
+	W float64
+	H float64
+}
+
+type asepriteFrame struct {
+	Frame      asepriteRect
+	Duration   float64
+	SourceSize struct {
+		W float64
+		H float64
+	}
+}
+
+// SliceBounds holds, for every slice name, the bound shapes of every frame of every animation,
+// following the same structure returned by bound.Deserialize.
+// A frame shape is nil if the slice is not defined for that frame
+type SliceBounds map[string]map[string][]bound.Shaper
+
+// DeserializeAseprite loads a JSON file exported by Aseprite, in either hash or array format, and returns
+// a Library with an animation for every frame tag, and the slices converted to bound boxes
+// relative to the center of the frames. Slices are also attached to the animation frames as shapes named after them.
+// Tags are played the number of times set in their repeat value, forever if not set.
+// Sprite sheets must be exported without trimming
+func DeserializeAseprite(r io.Reader) (*Library, SliceBounds, error) {
+	data := &AsepriteFile{}
+	err := json.NewDecoder(r).Decode(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(data.Meta.FrameTags) == 0 {
+		return nil, nil, fmt.Errorf(ErrorNoAnims)
+	}
+
+	frames, err := decodeAsepriteFrames(data.Frames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, tag := range data.Meta.FrameTags {
+		if _, ok := asepriteDirection[tag.Direction]; !ok {
+			return nil, nil, fmt.Errorf(ErrorDirectionNotSupported, tag.Direction, tag.Name)
+		}
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			return nil, nil, fmt.Errorf(ErrorFramesNotValid)
+		}
+		if _, ok := asepriteRepeat(tag.Repeat); !ok {
+			return nil, nil, fmt.Errorf(ErrorRepeatNotValid, tag.Repeat, tag.Name)
+		}
+	}
+
+	pic, err := quarter.LoadPicture(data.Meta.Image)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	height := data.Meta.Size.H
+	if height == 0 {
+		height = pic.Bounds().H()
+	}
+
+	lib := NewLibrary(len(data.Meta.FrameTags))
+	slices := make(SliceBounds, len(data.Meta.Slices))
+	for _, sl := range data.Meta.Slices {
+		slices[sl.Name] = make(map[string][]bound.Shaper, len(data.Meta.FrameTags))
+	}
+
+	for _, tag := range data.Meta.FrameTags {
+		rects := make([]pixel.Rect, 0, tag.To-tag.From+1)
+		durations := make([]float64, 0, tag.To-tag.From+1)
+		for i := tag.From; i <= tag.To; i++ {
+			f := frames[i].Frame
+			// Aseprite coordinates origin is at the top left corner, while Pixel's is at the bottom left one
+			rects = append(rects, pixel.R(f.X, height-f.Y-f.H, f.X+f.W, height-f.Y))
+			durations = append(durations, frames[i].Duration/1000)
+		}
+		if err := lib.AddFrames(tag.Name, pic, rects, durations, asepriteDirection[tag.Direction]); err != nil {
+			return nil, nil, err
+		}
+		loops, _ := asepriteRepeat(tag.Repeat)
+		lib.SetLoops(tag.Name, loops, true)
+
+		for _, sl := range data.Meta.Slices {
+			shapes := make([]bound.Shaper, 0, tag.To-tag.From+1)
+			for i := tag.From; i <= tag.To; i++ {
+				key := -1
+				// Every slice key applies from its frame on, until the next key
+				for k := range sl.Keys {
+					if sl.Keys[k].Frame <= i && (key == -1 || sl.Keys[k].Frame > sl.Keys[key].Frame) {
+						key = k
+					}
+				}
+				if key == -1 {
+					shapes = append(shapes, nil)
+					continue
+				}
+				b := sl.Keys[key].Bounds
+				w, h := frames[i].SourceSize.W, frames[i].SourceSize.H
+				box := bound.NewBox(
+					pixel.V(b.X-w/2, h/2-b.Y-b.H),
+					pixel.V(b.X+b.W-w/2, h/2-b.Y),
+				)
+				shapes = append(shapes, box)
+				lib.AddShape(tag.Name, sl.Name, i-tag.From, box)
+			}
+			slices[sl.Name][tag.Name] = shapes
+		}
+	}
+
+	return lib, slices, nil
+}
+
+// asepriteRepeat returns the number of loops of a tag from its repeat value, and false if it is not valid
+func asepriteRepeat(repeat string) (int, bool) {
+	if repeat == "" {
+		return 0, true
+	}
+	loops, err := strconv.Atoi(repeat)
+	return loops, err == nil && loops >= 0
+}
+
+// decodeAsepriteFrames returns frames in the same order they are declared, no matter if they are
+// stored in an array or in a hash
+func decodeAsepriteFrames(raw json.RawMessage) ([]asepriteFrame, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, fmt.Errorf(ErrorFramesNotValid)
+	}
+
+	frames := []asepriteFrame{}
+	if raw[0] == '[' {
+		if err := json.Unmarshal(raw, &frames); err != nil {
+			return nil, err
+		}
+		return frames, nil
+	}
+
+	// Hash keys are read one by one, as decoding them into a map would lose their order
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, fmt.Errorf(ErrorFramesNotValid)
+	}
+	for dec.More() {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		frame := asepriteFrame{}
+		if err := dec.Decode(&frame); err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
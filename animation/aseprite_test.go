@@ -0,0 +1,168 @@
+package animation_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/animation"
+	"github.com/svera/quarter/bound"
+)
+
+const asepriteHash = `{
+	"frames": {
+		"hero 0.aseprite": {"frame": {"x": 0, "y": 0, "w": 10, "h": 20}, "sourceSize": {"w": 10, "h": 20}, "duration": 250},
+		"hero 1.aseprite": {"frame": {"x": 10, "y": 0, "w": 10, "h": 20}, "sourceSize": {"w": 10, "h": 20}, "duration": 250},
+		"hero 2.aseprite": {"frame": {"x": 20, "y": 0, "w": 10, "h": 20}, "sourceSize": {"w": 10, "h": 20}, "duration": 250},
+		"hero 3.aseprite": {"frame": {"x": 30, "y": 0, "w": 10, "h": 20}, "sourceSize": {"w": 10, "h": 20}, "duration": 750}
+	},
+	"meta": {
+		"image": "%s",
+		"size": {"w": 40, "h": 20},
+		"frameTags": [
+			{"name": "walk", "from": 0, "to": 2, "direction": "pingpong"},
+			{"name": "attack", "from": 2, "to": 3, "direction": "forward"}
+		],
+		"slices": [
+			{"name": "hitbox", "keys": [{"frame": 1, "bounds": {"x": 2, "y": 4, "w": 6, "h": 16}}]}
+		]
+	}
+}`
+
+const asepriteArray = `{
+	"frames": [
+		{"filename": "hero 0.aseprite", "frame": {"x": 0, "y": 0, "w": 10, "h": 20}, "sourceSize": {"w": 10, "h": 20}, "duration": 250},
+		{"filename": "hero 1.aseprite", "frame": {"x": 10, "y": 0, "w": 10, "h": 20}, "sourceSize": {"w": 10, "h": 20}, "duration": 250},
+		{"filename": "hero 2.aseprite", "frame": {"x": 20, "y": 0, "w": 10, "h": 20}, "sourceSize": {"w": 10, "h": 20}, "duration": 250},
+		{"filename": "hero 3.aseprite", "frame": {"x": 30, "y": 0, "w": 10, "h": 20}, "sourceSize": {"w": 10, "h": 20}, "duration": 750}
+	],
+	"meta": {
+		"image": "%s",
+		"size": {"w": 40, "h": 20},
+		"frameTags": [
+			{"name": "walk", "from": 0, "to": 2, "direction": "pingpong"},
+			{"name": "attack", "from": 2, "to": 3, "direction": "forward"}
+		],
+		"slices": [
+			{"name": "hitbox", "keys": [{"frame": 1, "bounds": {"x": 2, "y": 4, "w": 6, "h": 16}}]}
+		]
+	}
+}`
+
+func writeSheet(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "aseprite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "hero.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 40, 20))); err != nil {
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestDeserializeAseprite(t *testing.T) {
+	path, cleanup := writeSheet(t)
+	defer cleanup()
+
+	formats := map[string]string{"hash": asepriteHash, "array": asepriteArray}
+	for format, data := range formats {
+		t.Run(fmt.Sprintf("Frames in %s format are loaded", format), func(t *testing.T) {
+			lib, slices, err := animation.DeserializeAseprite(bytes.NewReader([]byte(fmt.Sprintf(data, path))))
+			if err != nil {
+				t.Fatalf("Valid Aseprite data is not loaded: %s", err)
+			}
+			anim := lib.New(pixel.ZV)
+			if err := anim.SetCurrentAnim("walk"); err != nil {
+				t.Fatalf("Frame tags must be loaded as animations: %s", err)
+			}
+			expectedFrames := []int{1, 2, 1, 0, 1}
+			for _, expected := range expectedFrames {
+				anim.Update(0.25)
+				if anim.CurrentFrameNumber() != expected {
+					t.Errorf("Ping pong direction: expected frame %d, got %d", expected, anim.CurrentFrameNumber())
+				}
+			}
+
+			anim.SetCurrentAnim("attack")
+			expectedFrames = []int{1, 1, 0}
+			for i, dt := range []float64{0.25, 0.5, 0.25} {
+				anim.Update(dt)
+				if anim.CurrentFrameNumber() != expectedFrames[i] {
+					t.Errorf("Per frame durations: expected frame %d, got %d", expectedFrames[i], anim.CurrentFrameNumber())
+				}
+			}
+
+			hitbox := slices["hitbox"]["walk"]
+			if len(hitbox) != 3 {
+				t.Fatalf("Expected a shape for every frame, got %d", len(hitbox))
+			}
+			if hitbox[0] != nil {
+				t.Errorf("Slices must not be defined before their first key")
+			}
+			expectedBox := bound.NewBox(pixel.V(-3, -10), pixel.V(3, 6))
+			if box, ok := hitbox[2].(*bound.Box); !ok || box.Rect != expectedBox.Rect {
+				t.Errorf("Expected slice bounds %v, got %v", expectedBox, hitbox[2])
+			}
+
+			anim.SetCurrentAnim("walk")
+			anim.Update(0.5)
+			if shapes := anim.Shapes("hitbox"); len(shapes) != 1 || shapes[0].(*bound.Box).Rect != expectedBox.Rect {
+				t.Errorf("Expected slices to be attached to frames, got %v", shapes)
+			}
+		})
+	}
+
+	t.Run("Reverse ping pong tags are played the number of times they are repeated", func(t *testing.T) {
+		data := fmt.Sprintf(`{"frames": [
+				{"frame": {"x": 0, "y": 0, "w": 10, "h": 20}, "duration": 250},
+				{"frame": {"x": 10, "y": 0, "w": 10, "h": 20}, "duration": 250},
+				{"frame": {"x": 20, "y": 0, "w": 10, "h": 20}, "duration": 250}],
+			"meta": {"image": "%s", "frameTags": [{"name": "walk", "from": 0, "to": 2, "direction": "pingpong_reverse", "repeat": "1"}]}}`, path)
+		lib, _, err := animation.DeserializeAseprite(bytes.NewReader([]byte(data)))
+		if err != nil {
+			t.Fatalf("Valid Aseprite data is not loaded: %s", err)
+		}
+		anim := lib.New(pixel.ZV)
+		anim.SetCurrentAnim("walk")
+		expectedFrames := []int{1, 0, 1, 2, 2}
+		for _, expected := range expectedFrames {
+			anim.Update(0.25)
+			if anim.CurrentFrameNumber() != expected {
+				t.Errorf("Ping pong reverse direction: expected frame %d, got %d", expected, anim.CurrentFrameNumber())
+			}
+		}
+		if !anim.IsOver() {
+			t.Errorf("Expected animation to be over after being repeated")
+		}
+	})
+
+	t.Run("Tag repeat values must be numbers", func(t *testing.T) {
+		data := []byte(`{"frames": [{"frame": {"x": 0, "y": 0, "w": 10, "h": 20}, "duration": 250}],
+			"meta": {"frameTags": [{"name": "walk", "from": 0, "to": 0, "direction": "forward", "repeat": "twice"}]}}`)
+		_, _, err := animation.DeserializeAseprite(bytes.NewReader(data))
+		if err == nil || err.Error() != fmt.Sprintf(animation.ErrorRepeatNotValid, "twice", "walk") {
+			t.Errorf("Expected repeat not valid error, got %v", err)
+		}
+	})
+
+	t.Run("Unknown tag directions are not supported", func(t *testing.T) {
+		data := []byte(`{"frames": [{"frame": {"x": 0, "y": 0, "w": 10, "h": 20}, "duration": 250}],
+			"meta": {"frameTags": [{"name": "walk", "from": 0, "to": 0, "direction": "sideways"}]}}`)
+		_, _, err := animation.DeserializeAseprite(bytes.NewReader(data))
+		if err == nil || err.Error() != fmt.Sprintf(animation.ErrorDirectionNotSupported, "sideways", "walk") {
+			t.Errorf("Expected direction not supported error, got %v", err)
+		}
+	})
+}
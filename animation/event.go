@@ -0,0 +1,54 @@
+package animation
+
+// Event types
+const (
+	// EventFrame is emitted when a frame with declared events is shown, once per event
+	EventFrame = iota
+	// EventLoop is emitted every time an animation is completely played and starts again
+	EventLoop
+	// EventComplete is emitted when an animation is over
+	EventComplete
+)
+
+// Event holds information about something that happened while playing an animation
+type Event struct {
+	Type  int
+	Anim  string
+	Frame int
+	// Name is the name of the event as declared for the frame, only set for EventFrame events
+	Name string
+}
+
+// OnFrame sets the function called every time a frame with declared events is shown
+func (a *Animation) OnFrame(f func(Event)) {
+	a.onFrame = f
+}
+
+// OnLoop sets the function called every time an animation is completely played and starts again
+func (a *Animation) OnLoop(f func(Event)) {
+	a.onLoop = f
+}
+
+// OnComplete sets the function called when an animation is over
+func (a *Animation) OnComplete(f func(Event)) {
+	a.onComplete = f
+}
+
+// Events returns the events emitted during the last update, in the same order they happened.
+// The returned slice is reused on every update, so it must not be kept
+func (a *Animation) Events() []Event {
+	return a.events
+}
+
+func (a *Animation) emitFrameEvents() {
+	for _, name := range a.current().events[a.currentFrameNumber] {
+		a.emit(Event{Type: EventFrame, Anim: a.currentAnimID, Frame: a.currentFrameNumber, Name: name}, a.onFrame)
+	}
+}
+
+func (a *Animation) emit(e Event, callback func(Event)) {
+	a.events = append(a.events, e)
+	if callback != nil {
+		callback(e)
+	}
+}
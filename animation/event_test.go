@@ -0,0 +1,108 @@
+package animation_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/animation"
+)
+
+func TestEvents(t *testing.T) {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 30, 10))
+	frames := []pixel.Rect{pixel.R(0, 0, 10, 10), pixel.R(10, 0, 20, 10), pixel.R(20, 0, 30, 10)}
+
+	lib := animation.NewLibrary(1)
+	lib.AddFrames("walk", pic, frames, []float64{0.25, 0.25, 0.25}, animation.Circular)
+	lib.SetLoops("walk", 2, true)
+	lib.AddEvent("walk", 0, "footstep")
+	lib.AddEvent("walk", 2, "footstep")
+
+	an := lib.New(pixel.ZV)
+	an.SetCurrentAnim("walk")
+
+	var received []animation.Event
+	callback := func(e animation.Event) {
+		received = append(received, e)
+	}
+	an.OnFrame(callback)
+	an.OnLoop(callback)
+	an.OnComplete(callback)
+
+	var testValues = []struct {
+		testName       string
+		expectedEvents []animation.Event
+	}{
+		{"First frame events are emitted when animation starts", []animation.Event{
+			{Type: animation.EventFrame, Anim: "walk", Frame: 0, Name: "footstep"},
+		}},
+		{"No events are emitted for frames without them", nil},
+		{"Frame events are emitted when frame is shown", []animation.Event{
+			{Type: animation.EventFrame, Anim: "walk", Frame: 2, Name: "footstep"},
+		}},
+		{"Loop event is emitted before first frame events", []animation.Event{
+			{Type: animation.EventLoop, Anim: "walk", Frame: 2},
+			{Type: animation.EventFrame, Anim: "walk", Frame: 0, Name: "footstep"},
+		}},
+		{"No events are emitted for frames without them after loop", nil},
+		{"Frame events are emitted again", []animation.Event{
+			{Type: animation.EventFrame, Anim: "walk", Frame: 2, Name: "footstep"},
+		}},
+		{"Complete event is emitted when animation is over", []animation.Event{
+			{Type: animation.EventComplete, Anim: "walk", Frame: 2},
+		}},
+		{"No events are emitted once animation is over", nil},
+	}
+	for i, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			received = nil
+			dt := 0.25
+			if i == 0 {
+				dt = 0
+			}
+			an.Update(dt)
+			if !reflect.DeepEqual(received, tt.expectedEvents) {
+				t.Errorf("Expected callbacks with %v, got %v", tt.expectedEvents, received)
+			}
+			if len(an.Events()) != len(tt.expectedEvents) || (len(tt.expectedEvents) > 0 && !reflect.DeepEqual(an.Events(), tt.expectedEvents)) {
+				t.Errorf("Expected event queue %v, got %v", tt.expectedEvents, an.Events())
+			}
+		})
+	}
+}
+
+func TestEventsOnSkippedFrames(t *testing.T) {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 30, 10))
+	frames := []pixel.Rect{pixel.R(0, 0, 10, 10), pixel.R(10, 0, 20, 10), pixel.R(20, 0, 30, 10)}
+	lib := animation.NewLibrary(1)
+	lib.AddFrames("walk", pic, frames, []float64{0.25, 0.25, 0.25}, animation.Single)
+	lib.AddEvent("walk", 1, "footstep")
+	lib.AddEvent("walk", 2, "shoot")
+
+	an := lib.New(pixel.ZV)
+	an.SetCurrentAnim("walk")
+	an.Update(1)
+
+	expected := []animation.Event{
+		{Type: animation.EventFrame, Anim: "walk", Frame: 1, Name: "footstep"},
+		{Type: animation.EventFrame, Anim: "walk", Frame: 2, Name: "shoot"},
+		{Type: animation.EventComplete, Anim: "walk", Frame: 2},
+	}
+	if !reflect.DeepEqual(an.Events(), expected) {
+		t.Errorf("Expected events %v, got %v", expected, an.Events())
+	}
+}
+
+func TestAddEvent(t *testing.T) {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 10, 10))
+	lib := animation.NewLibrary(1)
+	lib.AddFrames("idle", pic, []pixel.Rect{pic.Bounds()}, []float64{0.1}, animation.Circular)
+
+	if err := lib.AddEvent("idle", 1, "blink"); err == nil || err.Error() != fmt.Sprintf(animation.ErrorFrameDoesNotExist, 1, "idle") {
+		t.Errorf("Expected frame does not exist error, got %v", err)
+	}
+	if err := lib.AddEvent("run", 0, "blink"); err == nil || err.Error() != fmt.Sprintf(animation.ErrorAnimationDoesNotExist, "run") {
+		t.Errorf("Expected animation does not exist error, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package animation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter"
+	"github.com/svera/quarter/bound"
+)
+
+// Library holds the frames of a set of animations, which are shared by all the animations created from it.
+// This way, a sheet needs to be loaded only once no matter how many instances of the same sprite are on screen,
+// and all of them can be drawn in a single pixel.Batch
+type Library struct {
+	// TimeScale multiplies the time passed to all animations created from the library on update,
+	// on top of the package TimeScale, so some sprites can be slowed down or sped up on their own
+	TimeScale float64
+	anims     map[string]*sequence
+	sheet     pixel.Picture
+}
+
+// NewLibrary returns a new empty Library instance
+func NewLibrary(numberAnims int) *Library {
+	return &Library{
+		TimeScale: 1,
+		anims:     make(map[string]*sequence, numberAnims),
+	}
+}
+
+// DeserializeLibrary loads an animations file and returns its information as a Library
+func DeserializeLibrary(r io.Reader) (*Library, error) {
+	data := &AnimFile{}
+	err := json.NewDecoder(r).Decode(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Version != "1" {
+		return nil, fmt.Errorf(ErrorVersionNotSupported, data.Version)
+	}
+
+	if len(data.Anims) == 0 {
+		return nil, fmt.Errorf(ErrorNoAnims)
+	}
+
+	pic, err := quarter.LoadPicture(data.Sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	lib := NewLibrary(len(data.Anims))
+	for i, an := range data.Anims {
+		durations := an.Durations
+		if len(durations) == 0 {
+			durations = uniformDurations(an.Frames, an.Duration)
+		} else if an.Frames == 0 {
+			an.Frames = len(durations)
+		} else if len(durations) != an.Frames {
+			return nil, fmt.Errorf(ErrorDurationsNotValid, i)
+		}
+		if err := lib.AddFrames(i, pic, rowFrames(an.YOffset, an.Width, an.Height, an.Frames), durations, animationCycle[an.Cycle]); err != nil {
+			return nil, err
+		}
+		hold := an.Hold == nil || *an.Hold
+		if err := lib.SetLoops(i, an.Loops, hold); err != nil {
+			return nil, err
+		}
+		if an.Anchor != nil {
+			if err := lib.SetAnchor(i, *an.Anchor); err != nil {
+				return nil, err
+			}
+		}
+		for _, ev := range an.Events {
+			if err := lib.AddEvent(i, ev.Frame, ev.Name); err != nil {
+				return nil, err
+			}
+		}
+		for name, frames := range an.Shapes {
+			if len(frames) != 1 && len(frames) != an.Frames {
+				return nil, fmt.Errorf(ErrorShapesNotValid, name, i)
+			}
+			for frame := 0; frame < an.Frames; frame++ {
+				for _, data := range frames[frame%len(frames)] {
+					s, err := data.Decode()
+					if err != nil {
+						return nil, err
+					}
+					if err := lib.AddShape(i, name, frame, s); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	return lib, nil
+}
+
+// AddAnim adds a new animation to the library, identified with ID,
+// whose frames are taken from pic from left to right, starting from X = 0
+// duration defines how many seconds should it take for the animation to complete a cycle
+func (l *Library) AddAnim(idx string, pic pixel.Picture, yOffset, width, height float64, numberFrames int, duration float64, cycle string) {
+	l.AddFrames(idx, pic, rowFrames(yOffset, width, height, numberFrames), uniformDurations(numberFrames, duration), animationCycle[cycle])
+}
+
+// rowFrames returns the bounds of numberFrames frames placed from left to right, starting from X = 0
+func rowFrames(yOffset, width, height float64, numberFrames int) []pixel.Rect {
+	frames := make([]pixel.Rect, numberFrames)
+	var x float64
+	for i := 0; i < numberFrames; i++ {
+		x = width * float64(i)
+		frames[i] = pixel.R(x, yOffset, x+width, yOffset+height)
+	}
+	return frames
+}
+
+func uniformDurations(numberFrames int, duration float64) []float64 {
+	durations := make([]float64, numberFrames)
+	for i := range durations {
+		durations[i] = duration / float64(numberFrames)
+	}
+	return durations
+}
+
+// AddFrames adds a new animation to the library, identified with ID, whose frames are taken from pic
+// using the passed bounds. durations holds how many seconds every frame is shown, and must have one entry per frame
+func (l *Library) AddFrames(idx string, pic pixel.Picture, frames []pixel.Rect, durations []float64, cycle int) error {
+	if len(durations) != len(frames) {
+		return fmt.Errorf(ErrorDurationsNotValid, idx)
+	}
+	l.sheet = pic
+	seq := &sequence{
+		frames:    make([]*pixel.Sprite, len(frames)),
+		durations: durations,
+		cycle:     cycle,
+		hold:      true,
+	}
+	for i, frame := range frames {
+		seq.frames[i] = pixel.NewSprite(pic, frame)
+	}
+	l.anims[idx] = seq
+	return nil
+}
+
+// SetLoops defines how many times the animation identified with ID is played, 0 meaning forever,
+// and whether it stays on its last frame when it is over or goes back to its first one.
+// Single cycle animations are always played once
+func (l *Library) SetLoops(idx string, loops int, hold bool) error {
+	seq, ok := l.anims[idx]
+	if !ok {
+		return fmt.Errorf(ErrorAnimationDoesNotExist, idx)
+	}
+	seq.loops = loops
+	seq.hold = hold
+	return nil
+}
+
+// SetAnchor defines the point of the frames of the animation identified with ID, relative to their
+// bottom left corner, which is placed at the animation position and used as origin for its transformations.
+// This keeps frames of different sizes aligned. By default, frames are placed by their center
+func (l *Library) SetAnchor(idx string, anchor pixel.Vec) error {
+	seq, ok := l.anims[idx]
+	if !ok {
+		return fmt.Errorf(ErrorAnimationDoesNotExist, idx)
+	}
+	seq.anchor = &anchor
+	return nil
+}
+
+// AddEvent attaches a named event to a frame of the animation identified with ID,
+// which is emitted every time that frame is shown
+func (l *Library) AddEvent(idx string, frame int, name string) error {
+	seq, ok := l.anims[idx]
+	if !ok {
+		return fmt.Errorf(ErrorAnimationDoesNotExist, idx)
+	}
+	if frame < 0 || frame >= len(seq.frames) {
+		return fmt.Errorf(ErrorFrameDoesNotExist, frame, idx)
+	}
+	if seq.events == nil {
+		seq.events = make(map[int][]string)
+	}
+	seq.events[frame] = append(seq.events[frame], name)
+	return nil
+}
+
+// AddShape attaches a bound shape, relative to the anchor point, to a frame of the animation identified with ID.
+// Frames can have many shapes, grouped by name, for example to tell hitboxes and hurtboxes apart
+func (l *Library) AddShape(idx, name string, frame int, s bound.Shaper) error {
+	seq, ok := l.anims[idx]
+	if !ok {
+		return fmt.Errorf(ErrorAnimationDoesNotExist, idx)
+	}
+	if frame < 0 || frame >= len(seq.frames) {
+		return fmt.Errorf(ErrorFrameDoesNotExist, frame, idx)
+	}
+	if seq.shapes == nil {
+		seq.shapes = make(map[string][][]bound.Shaper)
+	}
+	if _, ok := seq.shapes[name]; !ok {
+		seq.shapes[name] = make([][]bound.Shaper, len(seq.frames))
+	}
+	seq.shapes[name][frame] = append(seq.shapes[name][frame], s)
+	return nil
+}
+
+// New returns a new Animation instance to be drawn at position pos, which uses the library frames
+func (l *Library) New(pos pixel.Vec) *Animation {
+	return &Animation{
+		library:  l,
+		Position: pos,
+		Dir:      1,
+		Scale:    pixel.V(1, 1),
+		Mask:     pixel.Alpha(1),
+	}
+}
+
+// Picture returns the picture library frames are taken from
+func (l *Library) Picture() pixel.Picture {
+	return l.sheet
+}
+
+// SetPicture makes all frames of all animations to be taken from pic, keeping their bounds.
+// This is useful to use a recolored version of the sheet. As frames are shared, all animations created
+// from the library are affected, except the ones with their own picture set with Animation.SetPicture
+func (l *Library) SetPicture(pic pixel.Picture) {
+	l.sheet = pic
+	for _, anim := range l.anims {
+		for _, frame := range anim.frames {
+			frame.Set(pic, frame.Frame())
+		}
+	}
+}
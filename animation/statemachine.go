@@ -0,0 +1,241 @@
+package animation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Returned errors
+const (
+	ErrorStateDoesNotExist    = "State '%s' does not exist"
+	ErrorOperatorNotSupported = "Operator \"%s\" not supported"
+)
+
+// AnyState can be used as the origin of a transition to make it possible from every state
+const AnyState = "*"
+
+// StateGraphFile defines the structure of a disk file containing an animation state machine
+type StateGraphFile struct {
+	Version     string
+	Initial     string
+	States      map[string]string
+	Transitions []Transition
+}
+
+// Condition compares a named parameter of the state machine against a value.
+// The parameter type is defined by Op:
+// * ">", ">=", "<", "<=", "==" or "!=" compare a float parameter against Value.
+// * "true" or "false" check a bool parameter.
+// * An empty Op checks a trigger, which is consumed when the transition happens.
+type Condition struct {
+	Param string
+	Op    string
+	Value float64
+}
+
+// Transition defines when a state machine moves from a state to another
+type Transition struct {
+	// From is the origin state, or AnyState
+	From string
+	To   string
+	// Conditions must all be met for the transition to happen
+	Conditions []Condition
+	// WaitOver makes the transition wait until the animation of the origin state is over
+	WaitOver bool `json:"wait_over"`
+}
+
+// StateGraph holds the states and transitions of an animation state machine,
+// which can be shared by many state machines
+type StateGraph struct {
+	initial     string
+	states      map[string]string
+	transitions []Transition
+}
+
+// NewStateGraph returns a new StateGraph instance, whose state machines start at the initial state
+func NewStateGraph(initial string) *StateGraph {
+	return &StateGraph{
+		initial: initial,
+		states:  make(map[string]string),
+	}
+}
+
+// DeserializeStateGraph loads a state machine file and returns its information as a StateGraph
+func DeserializeStateGraph(r io.Reader) (*StateGraph, error) {
+	data := &StateGraphFile{}
+	err := json.NewDecoder(r).Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Version != "1" {
+		return nil, fmt.Errorf(ErrorVersionNotSupported, data.Version)
+	}
+
+	g := NewStateGraph(data.Initial)
+	for name, anim := range data.States {
+		g.AddState(name, anim)
+	}
+	for _, t := range data.Transitions {
+		if err := g.AddTransition(t); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := g.states[g.initial]; !ok {
+		return nil, fmt.Errorf(ErrorStateDoesNotExist, g.initial)
+	}
+	return g, nil
+}
+
+// AddState adds a state which plays the animation identified with anim
+func (g *StateGraph) AddState(name, anim string) {
+	g.states[name] = anim
+}
+
+// AddTransition adds a transition between two already added states.
+// Transitions are checked in the same order they are added
+func (g *StateGraph) AddTransition(t Transition) error {
+	if _, ok := g.states[t.From]; !ok && t.From != AnyState {
+		return fmt.Errorf(ErrorStateDoesNotExist, t.From)
+	}
+	if _, ok := g.states[t.To]; !ok {
+		return fmt.Errorf(ErrorStateDoesNotExist, t.To)
+	}
+	for _, c := range t.Conditions {
+		switch c.Op {
+		case ">", ">=", "<", "<=", "==", "!=", "true", "false", "":
+		default:
+			return fmt.Errorf(ErrorOperatorNotSupported, c.Op)
+		}
+	}
+	g.transitions = append(g.transitions, t)
+	return nil
+}
+
+// New returns a new StateMachine instance which controls anim, already set to the initial state animation.
+// The initial state and the animations of all states must exist
+func (g *StateGraph) New(anim *Animation) (*StateMachine, error) {
+	if _, ok := g.states[g.initial]; !ok {
+		return nil, fmt.Errorf(ErrorStateDoesNotExist, g.initial)
+	}
+	for _, id := range g.states {
+		if _, ok := anim.library.anims[id]; !ok {
+			return nil, fmt.Errorf(ErrorAnimationDoesNotExist, id)
+		}
+	}
+	sm := &StateMachine{
+		graph:    g,
+		anim:     anim,
+		floats:   make(map[string]float64),
+		bools:    make(map[string]bool),
+		triggers: make(map[string]bool),
+	}
+	if err := sm.enter(g.initial); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// StateMachine selects which animation to play depending on the values of its parameters
+type StateMachine struct {
+	graph    *StateGraph
+	anim     *Animation
+	current  string
+	floats   map[string]float64
+	bools    map[string]bool
+	triggers map[string]bool
+}
+
+// SetFloat sets the value of a float parameter
+func (sm *StateMachine) SetFloat(name string, value float64) {
+	sm.floats[name] = value
+}
+
+// SetBool sets the value of a bool parameter
+func (sm *StateMachine) SetBool(name string, value bool) {
+	sm.bools[name] = value
+}
+
+// SetTrigger activates a trigger parameter, which stays active until a transition which checks it happens
+func (sm *StateMachine) SetTrigger(name string) {
+	sm.triggers[name] = true
+}
+
+// State returns the current state name
+func (sm *StateMachine) State() string {
+	return sm.current
+}
+
+// Animation returns the animation controlled by the state machine
+func (sm *StateMachine) Animation() *Animation {
+	return sm.anim
+}
+
+// Update advances the controlled animation dt seconds and then checks transitions in order.
+// The first transition whose conditions are met is taken, if it does not lead to the current state.
+// An error is returned if the animation of the new state does not exist
+func (sm *StateMachine) Update(dt float64) error {
+	sm.anim.Update(dt)
+	for _, t := range sm.graph.transitions {
+		if t.From != AnyState && t.From != sm.current {
+			continue
+		}
+		if !sm.met(t) {
+			continue
+		}
+		for _, c := range t.Conditions {
+			if c.Op == "" {
+				delete(sm.triggers, c.Param)
+			}
+		}
+		if t.To != sm.current {
+			return sm.enter(t.To)
+		}
+		return nil
+	}
+	return nil
+}
+
+func (sm *StateMachine) met(t Transition) bool {
+	if t.WaitOver && !sm.anim.IsOver() {
+		return false
+	}
+	for _, c := range t.Conditions {
+		if !sm.check(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (sm *StateMachine) check(c Condition) bool {
+	v := sm.floats[c.Param]
+	switch c.Op {
+	case ">":
+		return v > c.Value
+	case ">=":
+		return v >= c.Value
+	case "<":
+		return v < c.Value
+	case "<=":
+		return v <= c.Value
+	case "==":
+		return v == c.Value
+	case "!=":
+		return v != c.Value
+	case "true":
+		return sm.bools[c.Param]
+	case "false":
+		return !sm.bools[c.Param]
+	}
+	return sm.triggers[c.Param]
+}
+
+func (sm *StateMachine) enter(state string) error {
+	if err := sm.anim.SetCurrentAnim(sm.graph.states[state]); err != nil {
+		return err
+	}
+	sm.current = state
+	return nil
+}
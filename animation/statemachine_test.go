@@ -0,0 +1,158 @@
+package animation_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/animation"
+)
+
+const heroStates = `{
+	"version": "1",
+	"initial": "idle",
+	"states": {"idle": "idle", "running": "running", "jumping": "jumping", "landing": "landing", "attacking": "attacking"},
+	"transitions": [
+		{"from": "*", "to": "attacking", "conditions": [{"param": "attack"}]},
+		{"from": "attacking", "to": "idle", "wait_over": true},
+		{"from": "attacking", "to": "attacking"},
+		{"from": "jumping", "to": "landing", "conditions": [{"param": "grounded", "op": "true"}]},
+		{"from": "landing", "to": "idle", "wait_over": true},
+		{"from": "landing", "to": "landing"},
+		{"from": "*", "to": "jumping", "conditions": [{"param": "vy", "op": ">", "value": 0}]},
+		{"from": "*", "to": "running", "conditions": [{"param": "vx", "op": "!=", "value": 0}]},
+		{"from": "*", "to": "idle"}
+	]
+}`
+
+func heroLibrary() *animation.Library {
+	pic := pixel.MakePictureData(pixel.R(0, 0, 20, 10))
+	frames := []pixel.Rect{pixel.R(0, 0, 10, 10), pixel.R(10, 0, 20, 10)}
+	lib := animation.NewLibrary(5)
+	for _, id := range []string{"idle", "running", "jumping"} {
+		lib.AddFrames(id, pic, frames, []float64{0.25, 0.25}, animation.Circular)
+	}
+	for _, id := range []string{"landing", "attacking"} {
+		lib.AddFrames(id, pic, frames, []float64{0.25, 0.25}, animation.Single)
+	}
+	return lib
+}
+
+func TestStateMachine(t *testing.T) {
+	graph, err := animation.DeserializeStateGraph(bytes.NewReader([]byte(heroStates)))
+	if err != nil {
+		t.Fatalf("Valid state machine data is not loaded: %s", err)
+	}
+	sm, err := graph.New(heroLibrary().New(pixel.ZV))
+	if err != nil {
+		t.Fatalf("State machine could not be created: %s", err)
+	}
+	if sm.State() != "idle" || sm.Animation().CurrentAnim() != "idle" {
+		t.Fatalf("State machine must start at initial state")
+	}
+
+	var testValues = []struct {
+		testName      string
+		set           func(sm *animation.StateMachine)
+		dt            float64
+		expectedState string
+	}{
+		{"Float condition greater than", func(sm *animation.StateMachine) { sm.SetFloat("vy", 10) }, 0, "jumping"},
+		{"Current state is kept while its conditions are met", func(sm *animation.StateMachine) { sm.SetFloat("vx", 5) }, 0, "jumping"},
+		{"Bool condition", func(sm *animation.StateMachine) { sm.SetBool("grounded", true); sm.SetFloat("vy", 0) }, 0, "landing"},
+		{"Wait over transition does not happen until animation is over", func(sm *animation.StateMachine) {}, 0.25, "landing"},
+		{"Wait over transition happens when animation is over", func(sm *animation.StateMachine) {}, 0.25, "idle"},
+		{"Float condition not equal", func(sm *animation.StateMachine) {}, 0, "running"},
+		{"Trigger condition", func(sm *animation.StateMachine) { sm.SetTrigger("attack") }, 0, "attacking"},
+		{"Trigger is consumed", func(sm *animation.StateMachine) {}, 0.25, "attacking"},
+		{"Transition without conditions", func(sm *animation.StateMachine) { sm.SetFloat("vx", 0) }, 0.25, "idle"},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.set(sm)
+			if err := sm.Update(tt.dt); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if sm.State() != tt.expectedState {
+				t.Errorf("Expected state \"%s\", got \"%s\"", tt.expectedState, sm.State())
+			}
+			if sm.Animation().CurrentAnim() != tt.expectedState {
+				t.Errorf("Expected animation \"%s\", got \"%s\"", tt.expectedState, sm.Animation().CurrentAnim())
+			}
+		})
+	}
+}
+
+func TestStateGraphErrors(t *testing.T) {
+	var testValues = []struct {
+		testName      string
+		data          string
+		expectedError string
+	}{
+		{
+			"Only version 1 is supported",
+			`{"version": "2", "initial": "idle", "states": {"idle": "idle"}}`,
+			fmt.Sprintf(animation.ErrorVersionNotSupported, "2"),
+		},
+		{
+			"Initial state must exist",
+			`{"version": "1", "initial": "idle", "states": {"running": "running"}}`,
+			fmt.Sprintf(animation.ErrorStateDoesNotExist, "idle"),
+		},
+		{
+			"Transition states must exist",
+			`{"version": "1", "initial": "idle", "states": {"idle": "idle"}, "transitions": [{"from": "idle", "to": "running"}]}`,
+			fmt.Sprintf(animation.ErrorStateDoesNotExist, "running"),
+		},
+		{
+			"Operators must be supported",
+			`{"version": "1", "initial": "idle", "states": {"idle": "idle"}, "transitions": [{"from": "*", "to": "idle", "conditions": [{"param": "vx", "op": "~"}]}]}`,
+			fmt.Sprintf(animation.ErrorOperatorNotSupported, "~"),
+		},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			_, err := animation.DeserializeStateGraph(bytes.NewReader([]byte(tt.data)))
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("Expected error \"%s\", got \"%v\"", tt.expectedError, err)
+			}
+		})
+	}
+
+	t.Run("State animations must exist in the animation library", func(t *testing.T) {
+		graph := animation.NewStateGraph("idle")
+		graph.AddState("idle", "sleeping")
+		_, err := graph.New(heroLibrary().New(pixel.ZV))
+		if err == nil || err.Error() != fmt.Sprintf(animation.ErrorAnimationDoesNotExist, "sleeping") {
+			t.Errorf("Expected animation does not exist error, got %v", err)
+		}
+	})
+
+	t.Run("Initial state must exist in the graph", func(t *testing.T) {
+		graph := animation.NewStateGraph("idle")
+		graph.AddState("running", "running")
+		_, err := graph.New(heroLibrary().New(pixel.ZV))
+		if err == nil || err.Error() != fmt.Sprintf(animation.ErrorStateDoesNotExist, "idle") {
+			t.Errorf("Expected state does not exist error, got %v", err)
+		}
+	})
+
+	t.Run("States added after creating the state machine must have an animation", func(t *testing.T) {
+		graph := animation.NewStateGraph("idle")
+		graph.AddState("idle", "idle")
+		sm, err := graph.New(heroLibrary().New(pixel.ZV))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		graph.AddState("sleeping", "sleeping")
+		graph.AddTransition(animation.Transition{From: "idle", To: "sleeping"})
+		err = sm.Update(0)
+		if err == nil || err.Error() != fmt.Sprintf(animation.ErrorAnimationDoesNotExist, "sleeping") {
+			t.Errorf("Expected animation does not exist error, got %v", err)
+		}
+		if sm.State() != "idle" {
+			t.Errorf("Expected state to be kept, got \"%s\"", sm.State())
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"image/color"
 	"io"
+	"math"
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
@@ -15,14 +16,33 @@ const (
 	ErrorVersionNotSupported   = "Version \"%s\" not supported"
 	ErrorShapeTypeNotSupported = "Shape type \"%s\" is not supported"
 	ErrorShapeDataNotValid     = "Shape data of shape type \"%s\" is not valid"
+	ErrorPolygonNotValid       = "Polygon must have at least 3 vertices defining a convex shape"
 )
 
 // Solution holds information about a collision if it happened, in which axis did and
 // what's the maximum distance an object can move without colliding with the target object
 type Solution struct {
 	CollisionAxis int
+	// Object is the first shape collided with
+	Object   Shaper
+	Distance pixel.Vec
+	// Contacts holds all shapes collided with
+	Contacts []Contact
+	// TOI is the fraction of the movement done before the first impact. Only set by Sweep
+	TOI float64
+	// Normal is the contact normal of the first impact, pointing away from the shapes hit. Only set by Sweep
+	Normal pixel.Vec
+	// Remaining is the movement left after the first impact, along the surface of the shapes hit
+	// so objects can slide over them. Only set by Sweep
+	Remaining pixel.Vec
+	// Triggers holds the trigger shapes collided with once moved, which do not block movement
+	Triggers []Shaper
+}
+
+// Contact holds a shape collided with and in which axis
+type Contact struct {
 	Object        Shaper
-	Distance      pixel.Vec
+	CollisionAxis int
 }
 
 // Possible collision axis values
@@ -40,20 +60,146 @@ type Shaper interface {
 // Shape is an interface that defines the minimum contract required for shapes that
 // can be checked for collisions
 type Shape interface {
+	Shaper
 	Collides(Shaper) bool
+	// Resolve checks if the shape will collide with other shapes if it moves a certain delta.
+	// Movement is resolved along X first and then along Y against all shapes,
+	// and the returned solution holds all the shapes touched
 	Resolve(pixel.Vec, ...Shaper) Solution
 	Draw(color *color.RGBA, imd *imdraw.IMDraw, target pixel.Target)
 	Align(pos pixel.Vec)
 }
 
+// Transform returns a copy of s after applying m to it. As boxes are axis aligned,
+// a transformed box is the smallest box which contains the transformed corners of the original one.
+// Circles keep their shape, so their radius is scaled by the largest scale factor of m.
+// Polygons and segments are transformed exactly, and slopes as long as m does not rotate them.
+// Collision filters are kept
+func Transform(s Shaper, m pixel.Matrix) Shape {
+	switch t := s.Shape().(type) {
+	case *Box:
+		min, max := m.Project(t.Min), m.Project(t.Min)
+		for _, corner := range t.Vertices() {
+			p := m.Project(corner)
+			min = pixel.V(math.Min(min.X, p.X), math.Min(min.Y, p.Y))
+			max = pixel.V(math.Max(max.X, p.X), math.Max(max.Y, p.Y))
+		}
+		bb := NewBox(min, max)
+		bb.Filter = t.Filter
+		return bb
+	case *Circle:
+		center := m.Project(t.Center)
+		scale := math.Max(math.Hypot(m[0], m[1]), math.Hypot(m[2], m[3]))
+		bc := NewCircle(center.X, center.Y, t.Radius*scale)
+		bc.Filter = t.Filter
+		return bc
+	case *Polygon:
+		vertices := make([]pixel.Vec, len(t.Vertices))
+		for i, v := range t.Vertices {
+			vertices[i] = m.Project(v)
+		}
+		// Affine transformations keep polygons convex, so there is no need to validate them again
+		p := newPolygon(vertices)
+		p.Filter = t.Filter
+		return p
+	case *Segment:
+		sg := NewSegment(m.Project(t.A), m.Project(t.B))
+		sg.Filter = t.Filter
+		return sg
+	case *Slope:
+		sl := NewSlope(m.Project(t.A), m.Project(t.B), m.Project(pixel.V(t.A.X, t.Bottom)).Y)
+		sl.Filter = t.Filter
+		return sl
+	}
+	return s.Shape()
+}
+
+// Translate moves s delta
+func Translate(s Shaper, delta pixel.Vec) {
+	switch t := s.Shape().(type) {
+	case *Box:
+		t.Rect = t.Moved(delta)
+	case *Circle:
+		t.Center = t.Center.Add(delta)
+	case *Polygon:
+		for i := range t.Vertices {
+			t.Vertices[i] = t.Vertices[i].Add(delta)
+		}
+	case *Segment:
+		t.A, t.B = t.A.Add(delta), t.B.Add(delta)
+	case *Slope:
+		t.A, t.B = t.A.Add(delta), t.B.Add(delta)
+		t.Bottom += delta.Y
+	}
+}
+
+// ShapeData is the JSON representation of a shape
+type ShapeData struct {
+	Type   string
+	Values json.RawMessage
+}
+
+// Decode returns the shape defined by the shape data
+func (d ShapeData) Decode() (Shaper, error) {
+	switch d.Type {
+	case "box":
+		bb := Box{Filter: NewFilter()}
+		err := json.Unmarshal(d.Values, &bb)
+		if err != nil {
+			return nil, fmt.Errorf(ErrorShapeDataNotValid, d.Type)
+		}
+		return &bb, nil
+	case "polygon":
+		p := Polygon{Filter: NewFilter()}
+		err := json.Unmarshal(d.Values, &p)
+		if err != nil {
+			return nil, fmt.Errorf(ErrorShapeDataNotValid, d.Type)
+		}
+		polygon, err := NewPolygon(p.Vertices...)
+		if err != nil {
+			return nil, fmt.Errorf(ErrorShapeDataNotValid, d.Type)
+		}
+		polygon.Filter = p.Filter
+		return polygon, nil
+	case "oriented_box":
+		ob := struct {
+			Center pixel.Vec
+			Size   pixel.Vec
+			Angle  float64
+			Filter
+		}{Filter: NewFilter()}
+		err := json.Unmarshal(d.Values, &ob)
+		if err != nil {
+			return nil, fmt.Errorf(ErrorShapeDataNotValid, d.Type)
+		}
+		polygon := NewOrientedBox(ob.Center, ob.Size, ob.Angle)
+		polygon.Filter = ob.Filter
+		return polygon, nil
+	case "segment":
+		sg := Segment{Filter: NewFilter()}
+		err := json.Unmarshal(d.Values, &sg)
+		if err != nil {
+			return nil, fmt.Errorf(ErrorShapeDataNotValid, d.Type)
+		}
+		return &sg, nil
+	case "slope":
+		sl := Slope{Filter: NewFilter()}
+		err := json.Unmarshal(d.Values, &sl)
+		if err != nil || sl.Bottom > math.Min(sl.A.Y, sl.B.Y) {
+			return nil, fmt.Errorf(ErrorShapeDataNotValid, d.Type)
+		}
+		slope := NewSlope(sl.A, sl.B, sl.Bottom)
+		slope.Filter = sl.Filter
+		return slope, nil
+	}
+	return nil, fmt.Errorf(ErrorShapeTypeNotSupported, d.Type)
+}
+
 // BoundFile is the struct of the JSON file used to store an animated sprite with attached bounding boxes
 type BoundFile struct {
 	Version string
 	Bounds  map[string]struct {
-		Shapes []struct {
-			Type   string
-			Values json.RawMessage
-		}
+		Shapes []ShapeData
 	}
 }
 
@@ -73,17 +219,11 @@ func Deserialize(r io.Reader) (map[string][]Shaper, error) {
 
 	for id, set := range data.Bounds {
 		for _, shape := range set.Shapes {
-			switch shape.Type {
-			case "box":
-				bb := Box{}
-				err := json.Unmarshal(shape.Values, &bb)
-				if err != nil {
-					return nil, fmt.Errorf(ErrorShapeDataNotValid, shape.Type)
-				}
-				bounds[id] = append(bounds[id], &bb)
-			default:
-				return nil, fmt.Errorf(ErrorShapeTypeNotSupported, shape.Type)
+			s, err := shape.Decode()
+			if err != nil {
+				return nil, err
 			}
+			bounds[id] = append(bounds[id], s)
 		}
 	}
 
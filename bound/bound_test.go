@@ -2,16 +2,20 @@ package bound_test
 
 import (
 	"bytes"
+	"fmt"
+	"image/color"
+	"math"
 	"reflect"
 	"testing"
 
 	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
 	"github.com/svera/quarter/bound"
 )
 
 func TestCollidesWithBoundingBox(t *testing.T) {
-	rect1 := &bound.Box{pixel.Rect{Min: pixel.V(5, 5), Max: pixel.V(55, 55)}}
-	rect2 := &bound.Box{pixel.Rect{Min: pixel.V(20, 10), Max: pixel.V(30, 20)}}
+	rect1 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(5, 5), Max: pixel.V(55, 55)}}
+	rect2 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(20, 10), Max: pixel.V(30, 20)}}
 
 	t.Run("Collision is detected", func(t *testing.T) {
 		if !rect1.Collides(rect2) {
@@ -41,13 +45,14 @@ func TestResolveBoxAgainstBox(t *testing.T) {
 					+-----+
 	*/
 	t.Run("rect1 moves right and down and collides with rect2", func(t *testing.T) {
-		rect1 := &bound.Box{pixel.Rect{Min: pixel.V(5, 20), Max: pixel.V(15, 30)}}
-		rect2 := &bound.Box{pixel.Rect{Min: pixel.V(20, 5), Max: pixel.V(30, 15)}}
+		rect1 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(5, 20), Max: pixel.V(15, 30)}}
+		rect2 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(20, 5), Max: pixel.V(30, 15)}}
 
 		expectedSolution := bound.Solution{
-			CollisionAxis: bound.AxisX,
+			CollisionAxis: bound.AxisY,
 			Object:        rect2,
-			Distance:      pixel.V(5, 0),
+			Distance:      pixel.V(0, -5),
+			Contacts:      []bound.Contact{{Object: rect2, CollisionAxis: bound.AxisY}},
 		}
 		sol := rect1.Resolve(pixel.V(10, -10), rect2)
 		if !reflect.DeepEqual(sol, expectedSolution) {
@@ -68,13 +73,14 @@ func TestResolveBoxAgainstBox(t *testing.T) {
 					+-----+
 	*/
 	t.Run("rect1 moves left and up and collides with rect2", func(t *testing.T) {
-		rect1 := &bound.Box{pixel.Rect{Min: pixel.V(20, 5), Max: pixel.V(30, 15)}}
-		rect2 := &bound.Box{pixel.Rect{Min: pixel.V(5, 20), Max: pixel.V(15, 30)}}
+		rect1 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(20, 5), Max: pixel.V(30, 15)}}
+		rect2 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(5, 20), Max: pixel.V(15, 30)}}
 
 		expectedSolution := bound.Solution{
-			CollisionAxis: bound.AxisX,
+			CollisionAxis: bound.AxisY,
 			Object:        rect2,
-			Distance:      pixel.V(-5, 0),
+			Distance:      pixel.V(0, 5),
+			Contacts:      []bound.Contact{{Object: rect2, CollisionAxis: bound.AxisY}},
 		}
 		sol := rect1.Resolve(pixel.V(-10, 10), rect2)
 		if !reflect.DeepEqual(sol, expectedSolution) {
@@ -89,13 +95,14 @@ func TestResolveBoxAgainstBox(t *testing.T) {
 		+-----+         +-----+
 	*/
 	t.Run("rect1 moves right and collides with rect2", func(t *testing.T) {
-		rect1 := &bound.Box{pixel.Rect{Min: pixel.V(5, 5), Max: pixel.V(15, 15)}}
-		rect2 := &bound.Box{pixel.Rect{Min: pixel.V(20, 5), Max: pixel.V(30, 15)}}
+		rect1 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(5, 5), Max: pixel.V(15, 15)}}
+		rect2 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(20, 5), Max: pixel.V(30, 15)}}
 
 		expectedSolution := bound.Solution{
 			CollisionAxis: bound.AxisX,
 			Object:        rect2,
 			Distance:      pixel.V(5, 0),
+			Contacts:      []bound.Contact{{Object: rect2, CollisionAxis: bound.AxisX}},
 		}
 		sol := rect1.Resolve(pixel.V(10, 0), rect2)
 		if !reflect.DeepEqual(sol, expectedSolution) {
@@ -116,13 +123,14 @@ func TestResolveBoxAgainstBox(t *testing.T) {
 		+--------+
 	*/
 	t.Run("rect1 moves down and collides with rect2", func(t *testing.T) {
-		rect1 := &bound.Box{pixel.Rect{Min: pixel.V(20, 20), Max: pixel.V(30, 30)}}
-		rect2 := &bound.Box{pixel.Rect{Min: pixel.V(10, 5), Max: pixel.V(40, 15)}}
+		rect1 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(20, 20), Max: pixel.V(30, 30)}}
+		rect2 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(10, 5), Max: pixel.V(40, 15)}}
 
 		expectedSolution := bound.Solution{
 			CollisionAxis: bound.AxisY,
 			Object:        rect2,
 			Distance:      pixel.V(0, -5),
+			Contacts:      []bound.Contact{{Object: rect2, CollisionAxis: bound.AxisY}},
 		}
 		sol := rect1.Resolve(pixel.V(0, -10), rect2)
 		if !reflect.DeepEqual(sol, expectedSolution) {
@@ -143,13 +151,14 @@ func TestResolveBoxAgainstBox(t *testing.T) {
 		  +-----+
 	*/
 	t.Run("rect1 moves up and collides with rect2", func(t *testing.T) {
-		rect1 := &bound.Box{pixel.Rect{Min: pixel.V(20, 5), Max: pixel.V(30, 15)}}
-		rect2 := &bound.Box{pixel.Rect{Min: pixel.V(10, 20), Max: pixel.V(40, 30)}}
+		rect1 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(20, 5), Max: pixel.V(30, 15)}}
+		rect2 := &bound.Box{Rect: pixel.Rect{Min: pixel.V(10, 20), Max: pixel.V(40, 30)}}
 
 		expectedSolution := bound.Solution{
 			CollisionAxis: bound.AxisY,
 			Object:        rect2,
 			Distance:      pixel.V(0, 5),
+			Contacts:      []bound.Contact{{Object: rect2, CollisionAxis: bound.AxisY}},
 		}
 		sol := rect1.Resolve(pixel.V(0, 10), rect2)
 		if !reflect.DeepEqual(sol, expectedSolution) {
@@ -158,6 +167,656 @@ func TestResolveBoxAgainstBox(t *testing.T) {
 	})
 }
 
+func TestResolveCircleAgainstBox(t *testing.T) {
+	var testValues = []struct {
+		testName         string
+		circle           *bound.Circle
+		box              *bound.Box
+		delta            pixel.Vec
+		expectedAxis     int
+		expectedDistance pixel.Vec
+	}{
+		{"circle moves right and down and collides with box", bound.NewCircle(10, 25, 5), bound.NewBox(pixel.V(20, 5), pixel.V(30, 15)), pixel.V(10, -10), bound.AxisY, pixel.V(0, -5)},
+		{"circle moves left and up and collides with box", bound.NewCircle(25, 10, 5), bound.NewBox(pixel.V(5, 20), pixel.V(15, 30)), pixel.V(-10, 10), bound.AxisY, pixel.V(0, 5)},
+		{"circle moves right and collides with box", bound.NewCircle(10, 10, 5), bound.NewBox(pixel.V(20, 5), pixel.V(30, 15)), pixel.V(10, 0), bound.AxisX, pixel.V(5, 0)},
+		{"circle moves down and collides with box", bound.NewCircle(25, 25, 5), bound.NewBox(pixel.V(10, 5), pixel.V(40, 15)), pixel.V(0, -10), bound.AxisY, pixel.V(0, -5)},
+		{"circle moves up and collides with box", bound.NewCircle(25, 10, 5), bound.NewBox(pixel.V(10, 20), pixel.V(40, 30)), pixel.V(0, 10), bound.AxisY, pixel.V(0, 5)},
+		{"circle moves down and lands on box corner", bound.NewCircle(7, 28, 5), bound.NewBox(pixel.V(10, 5), pixel.V(40, 21)), pixel.V(0, -3.5), bound.AxisY, pixel.V(0, -3)},
+		// Moving only along Y, the corner stops the circle instead of pushing it aside along X
+		{"circle moves down and is stopped by box corner instead of pushed aside", bound.NewCircle(5, 30, 5), bound.NewBox(pixel.V(8, 5), pixel.V(40, 21)), pixel.V(0, -10), bound.AxisY, pixel.V(0, -5)},
+		{"circle moves right and does not collide with box", bound.NewCircle(10, 10, 5), bound.NewBox(pixel.V(20, 5), pixel.V(30, 15)), pixel.V(4, 0), bound.AxisNone, pixel.ZV},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			expectedSolution := bound.Solution{
+				CollisionAxis: tt.expectedAxis,
+				Distance:      tt.expectedDistance,
+			}
+			if tt.expectedAxis != bound.AxisNone {
+				expectedSolution.Object = tt.box
+				expectedSolution.Contacts = []bound.Contact{{Object: tt.box, CollisionAxis: tt.expectedAxis}}
+			}
+			sol := tt.circle.Resolve(tt.delta, tt.box)
+			if !reflect.DeepEqual(sol, expectedSolution) {
+				t.Errorf("Wrong resolution values, expected %v, got %v", expectedSolution, sol)
+			}
+		})
+	}
+}
+
+func TestResolveCircleAgainstCircle(t *testing.T) {
+	var testValues = []struct {
+		testName         string
+		circle1          *bound.Circle
+		circle2          *bound.Circle
+		delta            pixel.Vec
+		expectedAxis     int
+		expectedDistance pixel.Vec
+	}{
+		{"circle1 moves right and collides with circle2", bound.NewCircle(10, 10, 5), bound.NewCircle(30, 10, 5), pixel.V(12, 0), bound.AxisX, pixel.V(10, 0)},
+		{"circle1 moves left and collides with circle2", bound.NewCircle(30, 10, 5), bound.NewCircle(10, 10, 5), pixel.V(-12, 0), bound.AxisX, pixel.V(-10, 0)},
+		{"circle1 moves down and collides with circle2", bound.NewCircle(10, 30, 5), bound.NewCircle(10, 10, 5), pixel.V(0, -15), bound.AxisY, pixel.V(0, -10)},
+		{"circle1 moves up and collides with circle2", bound.NewCircle(10, 10, 5), bound.NewCircle(10, 30, 5), pixel.V(0, 15), bound.AxisY, pixel.V(0, 10)},
+		{"circle1 moves down and lands on the side of circle2", bound.NewCircle(16, 30, 5), bound.NewCircle(10, 10, 5), pixel.V(0, -13), bound.AxisY, pixel.V(0, -12)},
+		{"circle1 moves right and touches circle2", bound.NewCircle(10, 10, 5), bound.NewCircle(30, 10, 5), pixel.V(10, 0), bound.AxisNone, pixel.ZV},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			expectedSolution := bound.Solution{
+				CollisionAxis: tt.expectedAxis,
+				Distance:      tt.expectedDistance,
+			}
+			if tt.expectedAxis != bound.AxisNone {
+				expectedSolution.Object = tt.circle2
+				expectedSolution.Contacts = []bound.Contact{{Object: tt.circle2, CollisionAxis: tt.expectedAxis}}
+			}
+			sol := tt.circle1.Resolve(tt.delta, tt.circle2)
+			if !reflect.DeepEqual(sol, expectedSolution) {
+				t.Errorf("Wrong resolution values, expected %v, got %v", expectedSolution, sol)
+			}
+		})
+	}
+}
+
+func TestResolveAgainstManyShapes(t *testing.T) {
+	floor := bound.NewBox(pixel.V(0, 0), pixel.V(50, 10))
+	tile1 := bound.NewBox(pixel.V(0, 0), pixel.V(10, 10))
+	tile2 := bound.NewBox(pixel.V(10, 0), pixel.V(20, 10))
+	wall := bound.NewBox(pixel.V(32, 10), pixel.V(40, 40))
+	farWall := bound.NewBox(pixel.V(36, 10), pixel.V(44, 40))
+
+	var testValues = []struct {
+		testName         string
+		shape            bound.Shape
+		delta            pixel.Vec
+		others           []bound.Shaper
+		expectedAxis     int
+		expectedDistance pixel.Vec
+		expectedContacts []bound.Contact
+	}{
+		{
+			"box lands on the seam between two boxes",
+			bound.NewBox(pixel.V(6, 12), pixel.V(10, 22)),
+			pixel.V(2, -4),
+			[]bound.Shaper{tile1, tile2},
+			bound.AxisY,
+			pixel.V(0, -2),
+			[]bound.Contact{{Object: tile1, CollisionAxis: bound.AxisY}, {Object: tile2, CollisionAxis: bound.AxisY}},
+		},
+		{
+			"box slightly sunk in the floor walks across the seam between two boxes",
+			bound.NewBox(pixel.V(6, 10-1e-12), pixel.V(10, 20-1e-12)),
+			pixel.V(4, 0),
+			[]bound.Shaper{tile1, tile2},
+			bound.AxisNone,
+			pixel.ZV,
+			nil,
+		},
+		{
+			"box moves into the corner between floor and wall",
+			bound.NewBox(pixel.V(20, 10), pixel.V(30, 20)),
+			pixel.V(5, -3),
+			[]bound.Shaper{floor, wall},
+			bound.AxisBoth,
+			pixel.V(2, 0),
+			[]bound.Contact{{Object: wall, CollisionAxis: bound.AxisX}, {Object: floor, CollisionAxis: bound.AxisY}},
+		},
+		{
+			"box is stopped by the closest wall",
+			bound.NewBox(pixel.V(20, 10), pixel.V(30, 20)),
+			pixel.V(10, 0),
+			[]bound.Shaper{farWall, wall},
+			bound.AxisX,
+			pixel.V(2, 0),
+			[]bound.Contact{{Object: wall, CollisionAxis: bound.AxisX}},
+		},
+		{
+			"box moving fast does not go through thin shapes",
+			bound.NewBox(pixel.V(0, 10), pixel.V(10, 20)),
+			pixel.V(100, 0),
+			[]bound.Shaper{bound.NewBox(pixel.V(50, 0), pixel.V(51, 30))},
+			bound.AxisX,
+			pixel.V(40, 0),
+			nil,
+		},
+		{
+			"circle moves into the corner between floor and wall",
+			bound.NewCircle(25, 15, 5),
+			pixel.V(5, -3),
+			[]bound.Shaper{floor, wall},
+			bound.AxisBoth,
+			pixel.V(2, 0),
+			[]bound.Contact{{Object: wall, CollisionAxis: bound.AxisX}, {Object: floor, CollisionAxis: bound.AxisY}},
+		},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			expectedSolution := bound.Solution{
+				CollisionAxis: tt.expectedAxis,
+				Distance:      tt.expectedDistance,
+				Contacts:      tt.expectedContacts,
+			}
+			if tt.expectedContacts == nil && tt.expectedAxis != bound.AxisNone {
+				expectedSolution.Contacts = []bound.Contact{{Object: tt.others[0], CollisionAxis: tt.expectedAxis}}
+			}
+			if len(expectedSolution.Contacts) > 0 {
+				expectedSolution.Object = expectedSolution.Contacts[0].Object
+			}
+			sol := tt.shape.Resolve(tt.delta, tt.others...)
+			if !reflect.DeepEqual(sol, expectedSolution) {
+				t.Errorf("Wrong resolution values, expected %v, got %v", expectedSolution, sol)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	wall := bound.NewBox(pixel.V(50, 0), pixel.V(51, 30))
+	farWall := bound.NewBox(pixel.V(70, 0), pixel.V(71, 30))
+	floor := bound.NewBox(pixel.V(-50, -10), pixel.V(50, 0))
+
+	var testValues = []struct {
+		testName          string
+		shape             bound.Shape
+		delta             pixel.Vec
+		others            []bound.Shaper
+		expectedAxis      int
+		expectedTOI       float64
+		expectedNormal    pixel.Vec
+		expectedRemaining pixel.Vec
+	}{
+		{"box moving fast hits thin wall", bound.NewBox(pixel.V(0, 10), pixel.V(10, 20)), pixel.V(100, 0), []bound.Shaper{farWall, wall}, bound.AxisX, 0.4, pixel.V(-1, 0), pixel.ZV},
+		{"box slides along floor", bound.NewBox(pixel.V(0, 10), pixel.V(10, 20)), pixel.V(20, -20), []bound.Shaper{floor}, bound.AxisY, 0.5, pixel.V(0, 1), pixel.V(10, 0)},
+		{"box hits circle", bound.NewBox(pixel.V(0, 0), pixel.V(10, 10)), pixel.V(100, 0), []bound.Shaper{bound.NewCircle(50, 5, 5)}, bound.AxisX, 0.35, pixel.V(-1, 0), pixel.ZV},
+		{"box does not hit anything", bound.NewBox(pixel.V(0, 40), pixel.V(10, 50)), pixel.V(100, 0), []bound.Shaper{wall, floor}, bound.AxisNone, 1, pixel.ZV, pixel.ZV},
+		{"circle moving fast hits thin wall", bound.NewCircle(0, 5, 5), pixel.V(100, 0), []bound.Shaper{wall}, bound.AxisX, 0.45, pixel.V(-1, 0), pixel.ZV},
+		{"circle hits box corner", bound.NewCircle(0, 14, 5), pixel.V(20, 0), []bound.Shaper{bound.NewBox(pixel.V(10, -10), pixel.V(30, 10))}, bound.AxisBoth, 0.35, pixel.V(-0.6, 0.8), pixel.V(8.32, 6.24)},
+		{"circle hits circle", bound.NewCircle(0, 0, 5), pixel.V(100, 0), []bound.Shaper{bound.NewCircle(50, 0, 5)}, bound.AxisX, 0.4, pixel.V(-1, 0), pixel.ZV},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			sol := bound.Sweep(tt.shape, tt.delta, tt.others...)
+			if sol.CollisionAxis != tt.expectedAxis {
+				t.Errorf("Expected collision axis %d, got %d", tt.expectedAxis, sol.CollisionAxis)
+			}
+			if math.Abs(sol.TOI-tt.expectedTOI) > 1e-9 {
+				t.Errorf("Expected time of impact %f, got %f", tt.expectedTOI, sol.TOI)
+			}
+			if sol.Distance.Sub(tt.delta.Scaled(tt.expectedTOI)).Len() > 1e-9 {
+				t.Errorf("Expected distance %v, got %v", tt.delta.Scaled(tt.expectedTOI), sol.Distance)
+			}
+			if sol.Normal.Sub(tt.expectedNormal).Len() > 1e-9 {
+				t.Errorf("Expected normal %v, got %v", tt.expectedNormal, sol.Normal)
+			}
+			if sol.Remaining.Sub(tt.expectedRemaining).Len() > 1e-9 {
+				t.Errorf("Expected remaining movement %v, got %v", tt.expectedRemaining, sol.Remaining)
+			}
+			if tt.expectedAxis != bound.AxisNone && sol.Object != tt.others[len(tt.others)-1] {
+				t.Errorf("Expected to hit %v, got %v", tt.others[len(tt.others)-1], sol.Object)
+			}
+		})
+	}
+}
+
+// customShape is a shape implemented outside the package, which only fulfills the Shape interface
+type customShape struct {
+	box *bound.Box
+}
+
+func (c *customShape) Shape() bound.Shape                             { return c }
+func (c *customShape) Collides(other bound.Shaper) bool               { return c.box.Collides(other) }
+func (c *customShape) Align(pos pixel.Vec)                            { c.box.Align(pos) }
+func (c *customShape) Draw(*color.RGBA, *imdraw.IMDraw, pixel.Target) {}
+func (c *customShape) Resolve(delta pixel.Vec, others ...bound.Shaper) bound.Solution {
+	return c.box.Resolve(delta, others...)
+}
+
+func TestSweepCustomShape(t *testing.T) {
+	shape := &customShape{bound.NewBox(pixel.V(0, 10), pixel.V(10, 20))}
+	wall := bound.NewBox(pixel.V(50, 0), pixel.V(60, 30))
+
+	sol := bound.Sweep(shape, pixel.V(100, 0), wall)
+	if sol.Object != wall || sol.TOI != 0.4 || sol.Distance != pixel.V(40, 0) {
+		t.Errorf("Expected shapes which are not sweepers to be resolved, got %+v", sol)
+	}
+	if sol := bound.Sweep(shape, pixel.V(20, 0), wall); sol.Object != nil || sol.TOI != 1 {
+		t.Errorf("Expected full movement without collisions, got %+v", sol)
+	}
+}
+
+// mustPolygon returns a new polygon with the passed vertices, which must define a convex polygon
+func mustPolygon(vertices ...pixel.Vec) *bound.Polygon {
+	p, err := bound.NewPolygon(vertices...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func TestNewPolygon(t *testing.T) {
+	var testValues = []struct {
+		testName      string
+		vertices      []pixel.Vec
+		expectedValid bool
+	}{
+		{"Convex polygon is valid", []pixel.Vec{pixel.V(0, 0), pixel.V(10, 0), pixel.V(10, 10), pixel.V(0, 10)}, true},
+		{"Clockwise polygon is valid", []pixel.Vec{pixel.V(0, 0), pixel.V(0, 10), pixel.V(10, 0)}, true},
+		{"Polygon without vertices is not valid", nil, false},
+		{"Polygon with less than 3 vertices is not valid", []pixel.Vec{pixel.V(0, 0), pixel.V(10, 0)}, false},
+		{"Polygon with aligned vertices is not valid", []pixel.Vec{pixel.V(0, 0), pixel.V(5, 0), pixel.V(10, 0)}, false},
+		{"Polygon with repeated vertices is not valid", []pixel.Vec{pixel.V(0, 0), pixel.V(10, 0), pixel.V(10, 0), pixel.V(0, 10)}, false},
+		{"Concave polygon is not valid", []pixel.Vec{pixel.V(0, 0), pixel.V(10, 0), pixel.V(5, 2), pixel.V(10, 10), pixel.V(0, 10)}, false},
+		{"Self intersecting polygon is not valid", []pixel.Vec{pixel.V(0, 0), pixel.V(10, 10), pixel.V(10, 0), pixel.V(0, 10)}, false},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			p, err := bound.NewPolygon(tt.vertices...)
+			if tt.expectedValid && (err != nil || p == nil) {
+				t.Errorf("Expected polygon to be valid, got error %v", err)
+			}
+			if !tt.expectedValid && (err == nil || err.Error() != bound.ErrorPolygonNotValid) {
+				t.Errorf("Expected error \"%s\", got \"%v\"", bound.ErrorPolygonNotValid, err)
+			}
+		})
+	}
+
+	t.Run("Empty polygons have empty bounds", func(t *testing.T) {
+		p := &bound.Polygon{}
+		if p.Bounds() != pixel.ZR || p.Center() != pixel.ZV {
+			t.Errorf("Expected empty bounds and center at origin, got %v and %v", p.Bounds(), p.Center())
+		}
+	})
+}
+
+func TestPolygonCollisions(t *testing.T) {
+	square := mustPolygon(pixel.V(0, 0), pixel.V(0, 10), pixel.V(10, 10), pixel.V(10, 0))
+	diamond := bound.NewOrientedBox(pixel.V(0, 0), pixel.V(10, 10), math.Pi/4)
+
+	var testValues = []struct {
+		testName         string
+		polygon          *bound.Polygon
+		other            bound.Shaper
+		expectedCollides bool
+		expectedMTV      pixel.Vec
+	}{
+		{"polygon collides with box", square, bound.NewBox(pixel.V(8, 0), pixel.V(20, 10)), true, pixel.V(-2, 0)},
+		{"polygon collides with circle", square, bound.NewCircle(12, 5, 3), true, pixel.V(-1, 0)},
+		{"polygon collides with polygon", square, mustPolygon(pixel.V(5, 9), pixel.V(15, 9), pixel.V(10, 20)), true, pixel.V(0, -1)},
+		{"polygon does not collide with polygon", square, mustPolygon(pixel.V(11, 0), pixel.V(20, 0), pixel.V(15, 10)), false, pixel.ZV},
+		{"oriented box does not collide with box overlapping its bounds", diamond, bound.NewBox(pixel.V(5, 5), pixel.V(10, 10)), false, pixel.ZV},
+		{"oriented box does not collide with circle overlapping its bounds", diamond, bound.NewCircle(6, 6, 2), false, pixel.ZV},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			if tt.polygon.Collides(tt.other) != tt.expectedCollides {
+				t.Errorf("Expected collision to be %t", tt.expectedCollides)
+			}
+			if tt.other.Shape().Collides(tt.polygon) != tt.expectedCollides {
+				t.Errorf("Expected collision to be %t when checked from the other shape", tt.expectedCollides)
+			}
+			mtv, _ := tt.polygon.MTV(tt.other)
+			if mtv.Sub(tt.expectedMTV).Len() > 1e-9 {
+				t.Errorf("Expected minimum translation vector %v, got %v", tt.expectedMTV, mtv)
+			}
+		})
+	}
+}
+
+func TestPolygonMovement(t *testing.T) {
+	slope := mustPolygon(pixel.V(0, 0), pixel.V(20, 0), pixel.V(20, 20))
+
+	t.Run("box falls on slope", func(t *testing.T) {
+		sol := bound.NewBox(pixel.V(2, 20), pixel.V(6, 24)).Sweep(pixel.V(0, -20), slope)
+		if math.Abs(sol.TOI-0.7) > 1e-9 {
+			t.Errorf("Expected time of impact 0.7, got %f", sol.TOI)
+		}
+		expectedNormal := pixel.V(-1, 1).Unit()
+		if sol.Normal.Sub(expectedNormal).Len() > 1e-9 {
+			t.Errorf("Expected normal %v, got %v", expectedNormal, sol.Normal)
+		}
+	})
+
+	t.Run("circle falls on slope", func(t *testing.T) {
+		sol := bound.NewCircle(5, 20, 2).Sweep(pixel.V(0, -20), slope)
+		expectedTOI := (15 - 2*math.Sqrt2) / 20
+		if math.Abs(sol.TOI-expectedTOI) > 1e-9 {
+			t.Errorf("Expected time of impact %f, got %f", expectedTOI, sol.TOI)
+		}
+	})
+
+	t.Run("oriented box moves right and collides with box", func(t *testing.T) {
+		box := bound.NewBox(pixel.V(20, -10), pixel.V(30, 10))
+		sol := bound.NewOrientedBox(pixel.V(0, 0), pixel.V(10, 10), math.Pi/4).Resolve(pixel.V(20, 0), box)
+		expectedDistance := 20 - 5*math.Sqrt2
+		if sol.CollisionAxis != bound.AxisX || math.Abs(sol.Distance.X-expectedDistance) > 1e-9 {
+			t.Errorf("Expected to move %f along X axis, got %v in axis %d", expectedDistance, sol.Distance, sol.CollisionAxis)
+		}
+	})
+
+	t.Run("box moves down and lands on polygon", func(t *testing.T) {
+		platform := mustPolygon(pixel.V(0, 0), pixel.V(40, 0), pixel.V(40, 10), pixel.V(0, 10))
+		sol := bound.NewBox(pixel.V(10, 15), pixel.V(20, 25)).Resolve(pixel.V(0, -10), platform)
+		if sol.CollisionAxis != bound.AxisY || math.Abs(sol.Distance.Y+5) > 1e-9 {
+			t.Errorf("Expected to move -5 along Y axis, got %v in axis %d", sol.Distance, sol.CollisionAxis)
+		}
+	})
+}
+
+func TestRaycast(t *testing.T) {
+	box := bound.NewBox(pixel.V(20, 0), pixel.V(30, 10))
+	circle := bound.NewCircle(50, 5, 5)
+	slope := mustPolygon(pixel.V(0, 0), pixel.V(20, 0), pixel.V(20, 20))
+	segment := bound.NewSegment(pixel.V(10, 0), pixel.V(0, 10))
+	trigger := bound.NewBox(pixel.V(10, 0), pixel.V(12, 10))
+	trigger.IsTrigger = true
+	masked := bound.NewBox(pixel.V(14, 0), pixel.V(16, 10))
+	masked.Category, masked.Mask = 2, 2
+
+	var testValues = []struct {
+		testName    string
+		origin      pixel.Vec
+		dir         pixel.Vec
+		maxDist     float64
+		shapes      []bound.Shaper
+		expectedHit bool
+		expected    bound.Hit
+	}{
+		{"ray hits closest shape", pixel.V(0, 5), pixel.V(1, 0), 100, []bound.Shaper{circle, box}, true, bound.Hit{Object: box, Point: pixel.V(20, 5), Normal: pixel.V(-1, 0), Distance: 20}},
+		{"ray hits circle", pixel.V(0, 5), pixel.V(2, 0), 100, []bound.Shaper{circle}, true, bound.Hit{Object: circle, Point: pixel.V(45, 5), Normal: pixel.V(-1, 0), Distance: 45}},
+		{"ray hits polygon", pixel.V(10, 30), pixel.V(0, -1), 50, []bound.Shaper{slope}, true, bound.Hit{Object: slope, Point: pixel.V(10, 10), Normal: pixel.V(-1, 1).Unit(), Distance: 20}},
+		{"ray hits segment", pixel.V(0, 0), pixel.V(1, 1), 50, []bound.Shaper{segment}, true, bound.Hit{Object: segment, Point: pixel.V(5, 5), Normal: pixel.V(-1, -1).Unit(), Distance: 5 * math.Sqrt2}},
+		{"ray does not reach shapes", pixel.V(0, 5), pixel.V(1, 0), 10, []bound.Shaper{circle, box}, false, bound.Hit{}},
+		{"shapes containing origin are ignored", pixel.V(25, 5), pixel.V(1, 0), 100, []bound.Shaper{box, circle}, true, bound.Hit{Object: circle, Point: pixel.V(45, 5), Normal: pixel.V(-1, 0), Distance: 20}},
+		{"ray without direction does not hit shapes", pixel.V(0, 5), pixel.ZV, 100, []bound.Shaper{circle, box}, false, bound.Hit{}},
+		{"ray goes through triggers and shapes it cannot collide with", pixel.V(0, 5), pixel.V(1, 0), 100, []bound.Shaper{trigger, masked, box}, true, bound.Hit{Object: box, Point: pixel.V(20, 5), Normal: pixel.V(-1, 0), Distance: 20}},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			hit, ok := bound.Raycast(tt.origin, tt.dir, tt.maxDist, bound.NewFilter(), tt.shapes...)
+			if ok != tt.expectedHit {
+				t.Fatalf("Expected hit to be %t", tt.expectedHit)
+			}
+			if hit.Object != tt.expected.Object || hit.Point.Sub(tt.expected.Point).Len() > 1e-9 ||
+				hit.Normal.Sub(tt.expected.Normal).Len() > 1e-9 || math.Abs(hit.Distance-tt.expected.Distance) > 1e-9 {
+				t.Errorf("Expected hit %v, got %v", tt.expected, hit)
+			}
+		})
+	}
+}
+
+func TestSegmentCollisions(t *testing.T) {
+	platform := bound.NewSegment(pixel.V(0, 10), pixel.V(40, 10))
+
+	t.Run("segment collides with box", func(t *testing.T) {
+		if !platform.Collides(bound.NewBox(pixel.V(5, 5), pixel.V(10, 15))) {
+			t.Errorf("Segment crosses box but no collision is detected")
+		}
+		if platform.Collides(bound.NewBox(pixel.V(5, 11), pixel.V(10, 15))) {
+			t.Errorf("Segment does not cross box but a collision is detected")
+		}
+	})
+
+	t.Run("box lands on segment", func(t *testing.T) {
+		sol := bound.NewBox(pixel.V(10, 15), pixel.V(20, 25)).Resolve(pixel.V(0, -10), platform)
+		if sol.CollisionAxis != bound.AxisY || math.Abs(sol.Distance.Y+5) > 1e-9 {
+			t.Errorf("Expected to move -5 along Y axis, got %v in axis %d", sol.Distance, sol.CollisionAxis)
+		}
+	})
+
+	t.Run("circle moving fast does not go through segment", func(t *testing.T) {
+		sol := bound.NewCircle(20, 50, 5).Sweep(pixel.V(0, -100), platform)
+		if math.Abs(sol.TOI-0.35) > 1e-9 || sol.Normal.Sub(pixel.V(0, 1)).Len() > 1e-9 {
+			t.Errorf("Expected time of impact 0.35 with normal %v, got %f with normal %v", pixel.V(0, 1), sol.TOI, sol.Normal)
+		}
+	})
+}
+
+func TestCollisionFilters(t *testing.T) {
+	const (
+		player = 1 << iota
+		enemy
+		wall
+	)
+	hero := bound.NewBox(pixel.V(0, 0), pixel.V(10, 10))
+	hero.Category, hero.Mask = player, wall
+	ghost := bound.NewBox(pixel.V(5, 0), pixel.V(15, 10))
+	ghost.Category, ghost.Mask = enemy, player|wall
+	block := bound.NewBox(pixel.V(12, 0), pixel.V(22, 10))
+	block.Category = wall
+	checkpoint := bound.NewBox(pixel.V(11, 0), pixel.V(12, 10))
+	checkpoint.IsTrigger = true
+
+	t.Run("Shapes collide only if both masks include the other category", func(t *testing.T) {
+		if hero.Collides(ghost) || ghost.Collides(hero) {
+			t.Errorf("Shapes whose masks do not include each other must not collide")
+		}
+		if !ghost.Collides(block) {
+			t.Errorf("Shapes whose masks include each other must collide")
+		}
+	})
+
+	t.Run("Shapes with an empty mask collide with none", func(t *testing.T) {
+		loner := bound.NewBox(pixel.V(12, 0), pixel.V(22, 10))
+		loner.Mask = 0
+		if loner.Collides(ghost) || ghost.Collides(loner) {
+			t.Errorf("Shapes with an empty mask must not collide")
+		}
+	})
+
+	t.Run("Resolve ignores shapes that cannot collide", func(t *testing.T) {
+		sol := hero.Resolve(pixel.V(5, 0), ghost, block)
+		if sol.CollisionAxis != bound.AxisX || sol.Distance.X != 2 || sol.Object != block {
+			t.Errorf("Expected to move 2 along X axis against block, got %v in axis %d", sol.Distance, sol.CollisionAxis)
+		}
+	})
+
+	t.Run("Triggers are reported but do not block movement", func(t *testing.T) {
+		mover := bound.NewBox(pixel.V(0, 0), pixel.V(10, 10))
+		sol := mover.Resolve(pixel.V(5, 0), checkpoint, block)
+		if sol.CollisionAxis != bound.AxisX || sol.Distance.X != 2 {
+			t.Errorf("Expected to move 2 along X axis, got %v in axis %d", sol.Distance, sol.CollisionAxis)
+		}
+		if len(sol.Triggers) != 1 || sol.Triggers[0] != checkpoint {
+			t.Errorf("Expected checkpoint to be triggered, got %v", sol.Triggers)
+		}
+		sol = mover.Resolve(pixel.V(0, 5), checkpoint)
+		if len(sol.Triggers) != 0 {
+			t.Errorf("Expected no triggers, got %v", sol.Triggers)
+		}
+		sol = mover.Sweep(pixel.V(5, 0), checkpoint)
+		if sol.TOI != 1 || len(sol.Triggers) != 1 {
+			t.Errorf("Expected sweep to go through the trigger and report it, got time of impact %f and triggers %v", sol.TOI, sol.Triggers)
+		}
+	})
+
+	t.Run("Sweep reports triggers passed through", func(t *testing.T) {
+		mover := bound.NewBox(pixel.V(0, 0), pixel.V(10, 10))
+		coin := bound.NewCircle(30, 5, 2)
+		coin.IsTrigger = true
+		sol := mover.Sweep(pixel.V(50, 0), checkpoint, coin)
+		if sol.TOI != 1 || len(sol.Triggers) != 2 || sol.Triggers[0] != checkpoint || sol.Triggers[1] != coin {
+			t.Errorf("Expected checkpoint and coin to be triggered, got time of impact %f and triggers %v", sol.TOI, sol.Triggers)
+		}
+		if sol := mover.Sweep(pixel.V(0, 50), checkpoint, coin); len(sol.Triggers) != 0 {
+			t.Errorf("Expected no triggers, got %v", sol.Triggers)
+		}
+	})
+}
+
+func TestOneWayPlatforms(t *testing.T) {
+	platform := bound.NewBox(pixel.V(0, 0), pixel.V(30, 5))
+	platform.OneWay = pixel.V(0, 1)
+
+	var testValues = []struct {
+		testName              string
+		box                   *bound.Box
+		delta                 pixel.Vec
+		expectedCollisionAxis int
+		expectedDistance      pixel.Vec
+	}{
+		{"Platform stops shapes falling from above", bound.NewBox(pixel.V(10, 10), pixel.V(20, 20)), pixel.V(0, -10), bound.AxisY, pixel.V(0, -5)},
+		{"Shapes jump through the platform from below", bound.NewBox(pixel.V(10, -15), pixel.V(20, -5)), pixel.V(0, 10), bound.AxisNone, pixel.ZV},
+		{"Shapes which were inside the platform fall through it", bound.NewBox(pixel.V(10, -2), pixel.V(20, 8)), pixel.V(0, -3), bound.AxisNone, pixel.ZV},
+		{"Platform does not stop shapes moving sideways", bound.NewBox(pixel.V(-12, 0), pixel.V(-2, 10)), pixel.V(5, 0), bound.AxisNone, pixel.ZV},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			sol := tt.box.Resolve(tt.delta, platform)
+			if sol.CollisionAxis != tt.expectedCollisionAxis || sol.Distance != tt.expectedDistance {
+				t.Errorf("Expected to move %v in axis %d, got %v in axis %d", tt.expectedDistance, tt.expectedCollisionAxis, sol.Distance, sol.CollisionAxis)
+			}
+		})
+	}
+
+	t.Run("Sweep honors one way platforms", func(t *testing.T) {
+		sol := bound.NewBox(pixel.V(10, 50), pixel.V(20, 60)).Sweep(pixel.V(0, -100), platform)
+		if math.Abs(sol.TOI-0.45) > 1e-9 {
+			t.Errorf("Expected time of impact 0.45, got %f", sol.TOI)
+		}
+		sol = bound.NewBox(pixel.V(10, -60), pixel.V(20, -50)).Sweep(pixel.V(0, 100), platform)
+		if sol.TOI != 1 {
+			t.Errorf("Expected to go through the platform, got time of impact %f", sol.TOI)
+		}
+	})
+}
+
+func TestSlopes(t *testing.T) {
+	slope := bound.NewSlope(pixel.V(20, 10), pixel.V(0, 0), 0)
+
+	var testValues = []struct {
+		testName              string
+		box                   *bound.Box
+		delta                 pixel.Vec
+		expectedCollisionAxis int
+		expectedDistanceY     float64
+	}{
+		{"Shapes walk up the slope", bound.NewBox(pixel.V(0, 1), pixel.V(4, 5)), pixel.V(2, -0.1), bound.AxisY, 1},
+		{"Shapes walk down the slope without leaving its surface", bound.NewBox(pixel.V(6, 4), pixel.V(10, 8)), pixel.V(-2, -0.1), bound.AxisY, -1},
+		{"Shapes walk up the slope without vertical movement", bound.NewBox(pixel.V(0, 1), pixel.V(4, 5)), pixel.V(2, 0), bound.AxisY, 1},
+		{"Shapes walk down the slope without vertical movement", bound.NewBox(pixel.V(6, 4), pixel.V(10, 8)), pixel.V(-2, 0), bound.AxisY, -1},
+		{"Shapes over the slope move freely without vertical movement", bound.NewBox(pixel.V(6, 10), pixel.V(10, 14)), pixel.V(2, 0), bound.AxisNone, 0},
+		{"Shapes land on the slope", bound.NewBox(pixel.V(6, 10), pixel.V(10, 14)), pixel.V(0, -20), bound.AxisY, -6},
+		{"Shapes over the slope fall freely", bound.NewBox(pixel.V(6, 10), pixel.V(10, 14)), pixel.V(0, -2), bound.AxisNone, 0},
+		{"Shapes jump off the slope", bound.NewBox(pixel.V(6, 4), pixel.V(10, 8)), pixel.V(0, 5), bound.AxisNone, 0},
+		{"Shapes under the slope surface are not stopped", bound.NewBox(pixel.V(6, 1), pixel.V(10, 5)), pixel.V(0, -0.5), bound.AxisNone, 0},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			sol := tt.box.Resolve(tt.delta, slope)
+			if sol.CollisionAxis != tt.expectedCollisionAxis || math.Abs(sol.Distance.Y-tt.expectedDistanceY) > 1e-9 {
+				t.Errorf("Expected to move %f along Y in axis %d, got %v in axis %d", tt.expectedDistanceY, tt.expectedCollisionAxis, sol.Distance, sol.CollisionAxis)
+			}
+		})
+	}
+
+	t.Run("Height follows the slope surface", func(t *testing.T) {
+		if slope.Height(5) != 2.5 || slope.Height(-5) != 0 || slope.Height(25) != 10 {
+			t.Errorf("Expected heights 2.5, 0 and 10, got %f, %f and %f", slope.Height(5), slope.Height(-5), slope.Height(25))
+		}
+	})
+
+	t.Run("Slopes collide as polygons", func(t *testing.T) {
+		if !bound.NewCircle(10, 6, 1.5).Collides(slope) || bound.NewCircle(10, 8, 1.5).Collides(slope) {
+			t.Errorf("Expected circle to collide only when touching the slope surface")
+		}
+	})
+}
+
+func TestAlignCircle(t *testing.T) {
+	circle := bound.NewCircle(10, 10, 5)
+	circle.Align(pixel.V(20, 30))
+	if circle.Circle != pixel.C(pixel.V(20, 30), 5) {
+		t.Errorf("Expected circle to be centered at %v, got %v", pixel.V(20, 30), circle.Center)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	m := pixel.IM.Scaled(pixel.ZV, 2).Moved(pixel.V(10, 10))
+
+	t.Run("Boxes are transformed", func(t *testing.T) {
+		box := bound.Transform(bound.NewBox(pixel.V(-1, -2), pixel.V(1, 2)), m.ScaledXY(pixel.V(10, 10), pixel.V(-1, 1)))
+		expected := pixel.R(8, 6, 12, 14)
+		if box.(*bound.Box).Rect != expected {
+			t.Errorf("Expected box %v, got %v", expected, box)
+		}
+	})
+
+	t.Run("Circles are transformed", func(t *testing.T) {
+		circle := bound.Transform(bound.NewCircle(1, 0, 3), m)
+		expected := pixel.C(pixel.V(12, 10), 6)
+		if circle.(*bound.Circle).Circle != expected {
+			t.Errorf("Expected circle %v, got %v", expected, circle)
+		}
+	})
+
+	t.Run("Polygons are transformed", func(t *testing.T) {
+		polygon := bound.Transform(mustPolygon(pixel.V(0, 0), pixel.V(1, 0), pixel.V(0, 1)), m)
+		expected := mustPolygon(pixel.V(10, 10), pixel.V(12, 10), pixel.V(10, 12))
+		if !reflect.DeepEqual(polygon, expected) {
+			t.Errorf("Expected polygon %v, got %v", expected, polygon)
+		}
+	})
+
+	t.Run("Collision filters are kept", func(t *testing.T) {
+		circle := bound.NewCircle(1, 0, 3)
+		circle.Filter = bound.Filter{Category: 2, IsTrigger: true}
+		if f := bound.Transform(circle, m).(bound.Filtered).CollisionFilter(); f != circle.Filter {
+			t.Errorf("Expected filter %v, got %v", circle.Filter, f)
+		}
+	})
+
+	t.Run("Original shapes are not modified", func(t *testing.T) {
+		box := bound.NewBox(pixel.V(-1, -2), pixel.V(1, 2))
+		bound.Transform(box, m)
+		if box.Rect != pixel.R(-1, -2, 1, 2) {
+			t.Errorf("Transformed box must be a copy")
+		}
+	})
+}
+
+func TestTranslate(t *testing.T) {
+	delta := pixel.V(2, 3)
+	var testValues = []struct {
+		testName string
+		shape    bound.Shape
+		expected bound.Shape
+	}{
+		{"Boxes are translated", bound.NewBox(pixel.V(0, 0), pixel.V(1, 1)), bound.NewBox(pixel.V(2, 3), pixel.V(3, 4))},
+		{"Circles are translated", bound.NewCircle(0, 0, 1), bound.NewCircle(2, 3, 1)},
+		{"Polygons are translated", mustPolygon(pixel.V(0, 0), pixel.V(1, 0), pixel.V(0, 1)), mustPolygon(pixel.V(2, 3), pixel.V(3, 3), pixel.V(2, 4))},
+		{"Segments are translated", bound.NewSegment(pixel.V(0, 0), pixel.V(1, 0)), bound.NewSegment(pixel.V(2, 3), pixel.V(3, 3))},
+		{"Slopes are translated", bound.NewSlope(pixel.V(0, 0), pixel.V(1, 1), 0), bound.NewSlope(pixel.V(2, 3), pixel.V(3, 4), 3)},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			bound.Translate(tt.shape, delta)
+			if !reflect.DeepEqual(tt.shape, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, tt.shape)
+			}
+		})
+	}
+}
+
 func TestLoad(t *testing.T) {
 	t.Run("Only valid JSON is supported", func(t *testing.T) {
 		levelData := []byte(``)
@@ -167,6 +826,75 @@ func TestLoad(t *testing.T) {
 		}
 	})
 
+	t.Run("Polygons and segments are loaded", func(t *testing.T) {
+		levelData := []byte(`{"version": "1", "bounds": {"idle": {"shapes": [
+			{"type": "polygon", "values": {"vertices": [{"x": 0, "y": 0}, {"x": 0, "y": 10}, {"x": 10, "y": 0}]}},
+			{"type": "oriented_box", "values": {"center": {"x": 5, "y": 5}, "size": {"x": 4, "y": 2}, "angle": 0}},
+			{"type": "segment", "values": {"a": {"x": 0, "y": 0}, "b": {"x": 10, "y": 0}}}
+		]}}}`)
+		bounds, err := bound.Deserialize(bytes.NewReader(levelData))
+		if err != nil {
+			t.Fatalf("Valid collision data is not loaded: %s", err)
+		}
+		triangle := mustPolygon(pixel.V(0, 0), pixel.V(0, 10), pixel.V(10, 0))
+		if !reflect.DeepEqual(bounds["idle"][0], triangle) {
+			t.Errorf("Expected polygon %v, got %v", triangle, bounds["idle"][0])
+		}
+		if bounds["idle"][1].(*bound.Polygon).Bounds() != pixel.R(3, 4, 7, 6) {
+			t.Errorf("Expected oriented box bounds %v, got %v", pixel.R(3, 4, 7, 6), bounds["idle"][1].(*bound.Polygon).Bounds())
+		}
+		if !reflect.DeepEqual(bounds["idle"][2], bound.NewSegment(pixel.V(0, 0), pixel.V(10, 0))) {
+			t.Errorf("Expected segment, got %v", bounds["idle"][2])
+		}
+	})
+
+	t.Run("Collision filters are loaded", func(t *testing.T) {
+		levelData := []byte(`{"version": "1", "bounds": {"idle": {"shapes": [
+			{"type": "box", "values": {"min": {"x": 0, "y": 0}, "max": {"x": 10, "y": 10}, "category": 2, "mask": 5, "is_trigger": true}},
+			{"type": "oriented_box", "values": {"center": {"x": 5, "y": 5}, "size": {"x": 4, "y": 2}, "angle": 0, "category": 4}}
+		]}}}`)
+		bounds, err := bound.Deserialize(bytes.NewReader(levelData))
+		if err != nil {
+			t.Fatalf("Valid collision data is not loaded: %s", err)
+		}
+		expected := bound.Filter{Category: 2, Mask: 5, IsTrigger: true}
+		if f := bounds["idle"][0].Shape().(bound.Filtered).CollisionFilter(); f != expected {
+			t.Errorf("Expected filter %v, got %v", expected, f)
+		}
+		expected = bound.Filter{Category: 4, Mask: bound.AllCategories}
+		if f := bounds["idle"][1].Shape().(bound.Filtered).CollisionFilter(); f != expected {
+			t.Errorf("Expected filter %v, got %v", expected, f)
+		}
+	})
+
+	t.Run("Slopes and one way shapes are loaded", func(t *testing.T) {
+		levelData := []byte(`{"version": "1", "bounds": {"idle": {"shapes": [
+			{"type": "slope", "values": {"a": {"x": 10, "y": 5}, "b": {"x": 0, "y": 0}, "bottom": 0}},
+			{"type": "box", "values": {"min": {"x": 0, "y": 0}, "max": {"x": 10, "y": 2}, "one_way": {"x": 0, "y": 1}}}
+		]}}}`)
+		bounds, err := bound.Deserialize(bytes.NewReader(levelData))
+		if err != nil {
+			t.Fatalf("Valid collision data is not loaded: %s", err)
+		}
+		if !reflect.DeepEqual(bounds["idle"][0], bound.NewSlope(pixel.V(0, 0), pixel.V(10, 5), 0)) {
+			t.Errorf("Expected slope, got %v", bounds["idle"][0])
+		}
+		if bounds["idle"][1].Shape().(bound.Filtered).CollisionFilter().OneWay != pixel.V(0, 1) {
+			t.Errorf("Expected one way box, got %v", bounds["idle"][1])
+		}
+		levelData = []byte(`{"version": "1", "bounds": {"idle": {"shapes": [{"type": "slope", "values": {"a": {"x": 10, "y": 5}, "b": {"x": 0, "y": 0}, "bottom": 3}}]}}}`)
+		if _, err := bound.Deserialize(bytes.NewReader(levelData)); err == nil || err.Error() != fmt.Sprintf(bound.ErrorShapeDataNotValid, "slope") {
+			t.Errorf("Expected shape data not valid error, got %v", err)
+		}
+	})
+
+	t.Run("Polygons need at least three vertices", func(t *testing.T) {
+		levelData := []byte(`{"version": "1", "bounds": {"idle": {"shapes": [{"type": "polygon", "values": {"vertices": [{"x": 0, "y": 0}]}}]}}}`)
+		if _, err := bound.Deserialize(bytes.NewReader(levelData)); err == nil || err.Error() != fmt.Sprintf(bound.ErrorShapeDataNotValid, "polygon") {
+			t.Errorf("Expected shape data not valid error, got %v", err)
+		}
+	})
+
 	t.Run("Only version 1 is supported", func(t *testing.T) {
 		levelData := []byte(`{"version": "1", "bounds": {"idle": {"shapes": []}}}`)
 		r := bytes.NewReader(levelData)
@@ -2,7 +2,6 @@ package bound
 
 import (
 	"image/color"
-	"math"
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
@@ -11,25 +10,33 @@ import (
 // Box is a Rect with methods to resolve collisions
 type Box struct {
 	pixel.Rect
+	Filter
 }
 
 // NewBox returns a new Box instance.
 func NewBox(min pixel.Vec, max pixel.Vec) *Box {
 	return &Box{
-		pixel.Rect{
+		Rect: pixel.Rect{
 			Min: min,
 			Max: max,
 		},
+		Filter: NewFilter(),
 	}
 }
 
 // Collides returns true if the bounding box collides with the passed shape
 func (bb *Box) Collides(other Shaper) bool {
+	if !bb.CanCollide(filterOf(other.Shape())) {
+		return false
+	}
 	switch t := other.Shape().(type) {
 	case *Box:
 		return bb.Intersects(t.Rect)
 	case *Circle:
 		return bb.IntersectCircle(t.Circle) != pixel.ZV
+	case convex:
+		_, ok := convexMTV(t.vertices(), bb)
+		return ok
 	}
 	return false
 }
@@ -43,93 +50,58 @@ func (bb *Box) Align(pos pixel.Vec) {
 	bb.Rect = bb.Moved(pos.Sub(bb.Center()))
 }
 
-// Resolve checks if the bounding box will collide with another bounding box if it moves
-// a certain delta
+// Resolve resolves the movement of the bounding box against other shapes. See Shape.Resolve
 func (bb *Box) Resolve(delta pixel.Vec, others ...Shaper) Solution {
-	sol := Solution{}
-
-	for _, other := range others {
-		switch t := other.Shape().(type) {
-		case *Box:
-			sol = bb.resolveAgainstBoundingBox(t, delta)
-			if sol.CollisionAxis != AxisNone {
-				return sol
-			}
-		case *Circle:
-			sol = bb.resolveAgainstBoundingCircle(t, delta)
-			if sol.CollisionAxis != AxisNone {
-				return sol
-			}
-		}
-	}
-	return sol
+	return resolve(bb, delta, others)
 }
 
-func (bb *Box) resolveAgainstBoundingBox(other *Box, delta pixel.Vec) Solution {
-	bbMoved := &Box{
-		bb.Moved(delta),
-	}
-
-	sol := Solution{
-		Object:        other,
-		CollisionAxis: AxisNone,
-	}
+// Sweep moves the bounding box along delta against other shapes. See Sweeper
+func (bb *Box) Sweep(delta pixel.Vec, others ...Shaper) Solution {
+	return sweep(bb, delta, others)
+}
 
-	if !bbMoved.Intersects(other.Rect) {
-		return sol
+func (bb *Box) impact(other Shape, delta pixel.Vec) (float64, pixel.Vec, bool) {
+	switch t := other.(type) {
+	case *Box:
+		return rayRect(bb.Center(), delta, expand(t.Rect, bb.Size().Scaled(0.5)))
+	case *Circle:
+		// Same as the circle moving in the opposite direction towards the box
+		toi, normal, ok := sweepCircleRect(t.Center, delta.Scaled(-1), t.Radius, bb.Rect)
+		return toi, normal.Scaled(-1), ok
+	case convex:
+		return sweepPolygons(rectVertices(bb.Rect), t.vertices(), delta)
 	}
+	return 0, pixel.ZV, false
+}
 
-	intersectRect := bbMoved.Intersect(other.Rect)
-	distance := bbMoved.Center().To(other.Center())
+func (bb *Box) limit(other Shape, offset pixel.Vec, axis int, d float64) (float64, bool) {
+	r := bb.Moved(offset)
+	// Area covered by the box along all its way, so it does not go through thin shapes
+	swept := r.Union(r.Moved(step(axis, d)))
 
-	if math.Abs(intersectRect.W()) <= math.Abs(intersectRect.H()) {
-		if distance.X < 0 {
-			sol.Distance.X = other.right() - bb.left()
-		} else {
-			sol.Distance.X = -(bb.right() - other.left())
+	switch t := other.(type) {
+	case *Box:
+		if !overlaps(swept, t.Rect) || !ahead(r.Center(), t.Center(), axis, d) {
+			return 0, false
 		}
-		sol.CollisionAxis = AxisX
-	} else {
-		if distance.Y > 0 {
-			sol.Distance.Y = other.bottom() - bb.top()
-		} else {
-			sol.Distance.Y = -(bb.bottom() - other.top())
+		if d > 0 {
+			return component(t.Min, axis) - component(r.Max, axis), true
 		}
-		sol.CollisionAxis = AxisY
-	}
-	return sol
-}
-
-func (bb *Box) resolveAgainstBoundingCircle(other *Circle, delta pixel.Vec) Solution {
-	bbMoved := &Box{
-		bb.Moved(delta),
-	}
-
-	distance := bbMoved.Center().To(other.Center)
-
-	d := other.Circle.IntersectRect(bbMoved.Rect)
-
-	if d == pixel.ZV {
-		return Solution{
-			CollisionAxis: AxisNone,
+		return component(t.Max, axis) - component(r.Min, axis), true
+	case *Circle:
+		if !overlapsCircle(swept, t.Circle) || !ahead(r.Center(), t.Center, axis, d) {
+			return 0, false
 		}
+		extent := circleExtent(t.Circle, r, axis)
+		if d > 0 {
+			return component(t.Center, axis) - extent - component(r.Max, axis), true
+		}
+		return component(t.Center, axis) + extent - component(r.Min, axis), true
+	case convex:
+		toi, _, ok := sweepPolygons(rectVertices(r), t.vertices(), step(axis, d))
+		return toi * d, ok
 	}
-
-	sol := Solution{
-		Object:        other,
-		Distance:      d,
-		CollisionAxis: AxisBoth,
-	}
-
-	if math.Abs(distance.X) > math.Abs(distance.Y) && d == pixel.ZV {
-		sol.CollisionAxis = AxisX
-	}
-
-	if math.Abs(distance.X) < math.Abs(distance.Y) && d == pixel.ZV {
-		sol.CollisionAxis = AxisY
-	}
-
-	return sol
+	return 0, false
 }
 
 // left returns BoundBox's left side X coordinate
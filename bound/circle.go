@@ -10,23 +10,31 @@ import (
 
 type Circle struct {
 	pixel.Circle
+	Filter
 }
 
 func NewCircle(x, y, r float64) *Circle {
 	return &Circle{
-		pixel.Circle{
+		Circle: pixel.Circle{
 			Center: pixel.V(x, y),
 			Radius: r,
 		},
+		Filter: NewFilter(),
 	}
 }
 
 func (bc *Circle) Collides(other Shaper) bool {
+	if !bc.CanCollide(filterOf(other.Shape())) {
+		return false
+	}
 	switch t := other.Shape().(type) {
 	case *Box:
 		return bc.IntersectRect(t.Rect) != pixel.ZV
 	case *Circle:
 		return bc.Intersect(t.Circle).Radius != 0
+	case convex:
+		_, ok := convexMTV(t.vertices(), bc)
+		return ok
 	}
 	return false
 }
@@ -35,40 +43,64 @@ func (bc *Circle) Shape() Shape {
 	return bc
 }
 
-// TODO
-func (bb *Circle) Align(pos pixel.Vec) {
-	return
+// Align moves the circle so its center is at pos
+func (bc *Circle) Align(pos pixel.Vec) {
+	bc.Center = pos
 }
 
-// TODO
+// Resolve resolves the movement of the bounding circle against other shapes. See Shape.Resolve
 func (bc *Circle) Resolve(delta pixel.Vec, others ...Shaper) Solution {
-	sol := Solution{}
+	return resolve(bc, delta, others)
+}
 
-	return sol
+// Sweep moves the bounding circle along delta against other shapes. See Sweeper
+func (bc *Circle) Sweep(delta pixel.Vec, others ...Shaper) Solution {
+	return sweep(bc, delta, others)
 }
 
-// This code is wrong, get the one from bb
-func (bc *Circle) resolveAgainstBoundingBox(other *Box, distance pixel.Vec) Solution {
-	sol := Solution{
-		Object: other,
+func (bc *Circle) impact(other Shape, delta pixel.Vec) (float64, pixel.Vec, bool) {
+	switch t := other.(type) {
+	case *Box:
+		return sweepCircleRect(bc.Center, delta, bc.Radius, t.Rect)
+	case *Circle:
+		return rayCircle(bc.Center, delta, pixel.C(t.Center, bc.Radius+t.Radius))
+	case convex:
+		return sweepCirclePolygon(bc.Center, delta, bc.Radius, t.vertices())
 	}
+	return 0, pixel.ZV, false
+}
 
-	if math.Abs(distance.X) > math.Abs(distance.Y) {
-		if distance.X < 0 {
-			sol.Distance.X = other.right() - bc.Radius
-		} else {
-			sol.Distance.X = -(bc.Radius - other.left())
+func (bc *Circle) limit(other Shape, offset pixel.Vec, axis int, d float64) (float64, bool) {
+	c := pixel.C(bc.Center.Add(offset), bc.Radius)
+
+	// Shapes are checked against the whole way of the circle, so it does not go through thin shapes
+	switch t := other.(type) {
+	case *Box:
+		if sweptDistance(t.Rect, c.Center, axis, d) >= bc.Radius-tolerance || !ahead(c.Center, t.Center(), axis, d) {
+			return 0, false
+		}
+		extent := circleExtent(c, t.Rect, axis)
+		if d > 0 {
+			return component(t.Min, axis) - extent - component(c.Center, axis), true
+		}
+		return component(t.Max, axis) + extent - component(c.Center, axis), true
+	case *Circle:
+		radius := bc.Radius + t.Radius
+		if sweptDistance(pixel.R(t.Center.X, t.Center.Y, t.Center.X, t.Center.Y), c.Center, axis, d) >= radius-tolerance || !ahead(c.Center, t.Center, axis, d) {
+			return 0, false
 		}
-		sol.CollisionAxis = AxisX
-	} else {
-		if distance.Y > 0 {
-			sol.Distance.Y = other.top() - bc.Radius
-		} else {
-			sol.Distance.Y = -(bc.Radius - other.top())
+		// How far both circles reach along axis, given their distance in the other axis
+		dist := component(t.Center, otherAxis(axis)) - component(c.Center, otherAxis(axis))
+		extent := math.Sqrt(math.Max(radius*radius-dist*dist, 0))
+		if d > 0 {
+			return component(t.Center, axis) - extent - component(c.Center, axis), true
 		}
-		sol.CollisionAxis = AxisY
+		return component(t.Center, axis) + extent - component(c.Center, axis), true
+	case convex:
+		toi, _, ok := sweepCirclePolygon(c.Center, step(axis, d), bc.Radius, t.vertices())
+		return toi * d, ok
 	}
-	return sol
+	return 0, false
 }
 
 func (bc *Circle) Draw(color *color.RGBA, imd *imdraw.IMDraw, target pixel.Target) {
@@ -0,0 +1,108 @@
+package bound
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+)
+
+// Collision categories
+const (
+	// DefaultCategory is the category of shapes which do not define one
+	DefaultCategory uint32 = 1
+	// AllCategories can be used as mask to collide with shapes of every category
+	AllCategories uint32 = math.MaxUint32
+)
+
+// Filtered is implemented by shapes with a collision filter, as all shapes in this package are.
+// Shapes which do not implement it use the filter returned by NewFilter
+type Filtered interface {
+	CollisionFilter() Filter
+}
+
+// Filter defines which shapes collide with each other, and whether a shape blocks the movement of others
+type Filter struct {
+	// Category holds the bits of the collision layers the shape belongs to. 0 means DefaultCategory
+	Category uint32
+	// Mask holds the bits of the collision layers the shape collides with, so 0 means none.
+	// Filters without category nor mask, like the ones of shapes not created by their constructors,
+	// collide with all categories
+	Mask uint32
+	// IsTrigger marks shapes which detect collisions but do not block movement, like checkpoints or pickups
+	IsTrigger bool `json:"is_trigger"`
+	// OneWay, if set, is the direction the shape is solid from, like (0, 1) for platforms which can be jumped through
+	// from below. Shapes only collide with it if they were on that side before moving and move against it
+	OneWay pixel.Vec `json:"one_way"`
+}
+
+// NewFilter returns the filter shapes are created with, which belongs to DefaultCategory and collides with all categories
+func NewFilter() Filter {
+	return Filter{Category: DefaultCategory, Mask: AllCategories}
+}
+
+// CollisionFilter returns the collision filter of a shape
+func (f Filter) CollisionFilter() Filter {
+	return f
+}
+
+// CanCollide returns true if the categories of shapes with filters f and other are in the mask of the other one
+func (f Filter) CanCollide(other Filter) bool {
+	return f.mask()&other.category() != 0 && other.mask()&f.category() != 0
+}
+
+func (f Filter) category() uint32 {
+	if f.Category == 0 {
+		return DefaultCategory
+	}
+	return f.Category
+}
+
+func (f Filter) mask() uint32 {
+	if f.Mask == 0 && f.Category == 0 {
+		return AllCategories
+	}
+	return f.Mask
+}
+
+// filterOf returns the collision filter of s
+func filterOf(s Shape) Filter {
+	if f, ok := s.(Filtered); ok {
+		return f.CollisionFilter()
+	}
+	return NewFilter()
+}
+
+// blocks returns true if b stops the movement of a
+func blocks(a, b Shape) bool {
+	fa, fb := filterOf(a), filterOf(b)
+	return fa.CanCollide(fb) && !fa.IsTrigger && !fb.IsTrigger
+}
+
+// passesThrough returns true if b is a one way shape which does not stop a moving move once moved offset
+func passesThrough(a Shape, offset, move pixel.Vec, b Shape) bool {
+	dir := filterOf(b).OneWay
+	if dir == pixel.ZV {
+		return false
+	}
+	dir = dir.Unit()
+	if move.Dot(dir) >= 0 {
+		return true
+	}
+	min, _ := extent(a, dir)
+	_, max := extent(b, dir)
+	return min+offset.Dot(dir) < max-tolerance
+}
+
+// extent returns the projection of s over axis
+func extent(s Shape, axis pixel.Vec) (float64, float64) {
+	switch t := s.(type) {
+	case *Box:
+		return project(rectVertices(t.Rect), axis)
+	case *Circle:
+		c := t.Center.Dot(axis)
+		return c - t.Radius, c + t.Radius
+	case convex:
+		return project(t.vertices(), axis)
+	}
+	return 0, 0
+}
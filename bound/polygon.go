@@ -0,0 +1,350 @@
+package bound
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+)
+
+// Polygon is a convex polygon with methods to resolve collisions,
+// useful to model slopes and rotated objects
+type Polygon struct {
+	// Vertices holds the polygon vertices in counter clockwise order
+	Vertices []pixel.Vec
+	Filter
+}
+
+// NewPolygon returns a new Polygon instance, or an error if vertices do not define a convex polygon.
+// Vertices are stored in counter clockwise order no matter the order they are passed in
+func NewPolygon(vertices ...pixel.Vec) (*Polygon, error) {
+	p := newPolygon(append([]pixel.Vec(nil), vertices...))
+	if !isConvex(p.Vertices) {
+		return nil, fmt.Errorf(ErrorPolygonNotValid)
+	}
+	return p, nil
+}
+
+// newPolygon returns a new Polygon instance using vertices, in counter clockwise order, without validating them
+func newPolygon(vertices []pixel.Vec) *Polygon {
+	if signedArea(vertices) < 0 {
+		for i, j := 0, len(vertices)-1; i < j; i, j = i+1, j-1 {
+			vertices[i], vertices[j] = vertices[j], vertices[i]
+		}
+	}
+	return &Polygon{Vertices: vertices, Filter: NewFilter()}
+}
+
+// isConvex returns true if the counter clockwise vertices define a convex polygon with some area,
+// which happens when all of them are at the left side of every edge or on it
+func isConvex(vertices []pixel.Vec) bool {
+	if len(vertices) < 3 || signedArea(vertices) < tolerance {
+		return false
+	}
+	for i, v := range vertices {
+		edge := v.To(vertices[(i+1)%len(vertices)])
+		if edge.Len() < tolerance {
+			return false
+		}
+		for _, w := range vertices {
+			if edge.Cross(v.To(w)) < -tolerance {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// NewOrientedBox returns a new Polygon instance with the shape of a box of the passed size,
+// centered at center and rotated angle radians
+func NewOrientedBox(center, size pixel.Vec, angle float64) *Polygon {
+	half := size.Scaled(0.5)
+	vertices := make([]pixel.Vec, 4)
+	for i, corner := range []pixel.Vec{pixel.V(-1, -1), pixel.V(1, -1), pixel.V(1, 1), pixel.V(-1, 1)} {
+		vertices[i] = pixel.V(corner.X*half.X, corner.Y*half.Y).Rotated(angle).Add(center)
+	}
+	return newPolygon(vertices)
+}
+
+// Shape returns the Polygon instance
+func (p *Polygon) Shape() Shape {
+	return p
+}
+
+// Center returns the average of the polygon vertices
+func (p *Polygon) Center() pixel.Vec {
+	if len(p.Vertices) == 0 {
+		return pixel.ZV
+	}
+	var sum pixel.Vec
+	for _, v := range p.Vertices {
+		sum = sum.Add(v)
+	}
+	return sum.Scaled(1 / float64(len(p.Vertices)))
+}
+
+// Bounds returns the smallest rect which contains the polygon
+func (p *Polygon) Bounds() pixel.Rect {
+	if len(p.Vertices) == 0 {
+		return pixel.ZR
+	}
+	r := pixel.Rect{Min: p.Vertices[0], Max: p.Vertices[0]}
+	for _, v := range p.Vertices[1:] {
+		r.Min = pixel.V(math.Min(r.Min.X, v.X), math.Min(r.Min.Y, v.Y))
+		r.Max = pixel.V(math.Max(r.Max.X, v.X), math.Max(r.Max.Y, v.Y))
+	}
+	return r
+}
+
+// Align moves the polygon so its center is at pos
+func (p *Polygon) Align(pos pixel.Vec) {
+	delta := p.Center().To(pos)
+	for i := range p.Vertices {
+		p.Vertices[i] = p.Vertices[i].Add(delta)
+	}
+}
+
+// Collides returns true if the polygon collides with the passed shape
+func (p *Polygon) Collides(other Shaper) bool {
+	if !p.CanCollide(filterOf(other.Shape())) {
+		return false
+	}
+	_, ok := p.MTV(other)
+	return ok
+}
+
+// MTV returns the minimum translation vector, which is the shortest movement needed to separate
+// the polygon from the passed shape, if they collide
+func (p *Polygon) MTV(other Shaper) (pixel.Vec, bool) {
+	return convexMTV(p.Vertices, other.Shape())
+}
+
+// Resolve resolves the movement of the polygon against other shapes. See Shape.Resolve
+func (p *Polygon) Resolve(delta pixel.Vec, others ...Shaper) Solution {
+	return resolve(p, delta, others)
+}
+
+// Sweep moves the polygon along delta against other shapes. See Sweeper
+func (p *Polygon) Sweep(delta pixel.Vec, others ...Shaper) Solution {
+	return sweep(p, delta, others)
+}
+
+func (p *Polygon) impact(other Shape, delta pixel.Vec) (float64, pixel.Vec, bool) {
+	return sweepPolygon(p.Vertices, other, delta)
+}
+
+func (p *Polygon) limit(other Shape, offset pixel.Vec, axis int, d float64) (float64, bool) {
+	vertices := make([]pixel.Vec, len(p.Vertices))
+	for i, v := range p.Vertices {
+		vertices[i] = v.Add(offset)
+	}
+	toi, _, ok := sweepPolygon(vertices, other, step(axis, d))
+	return toi * d, ok
+}
+
+// Draw draws the polygon surface on the passed target with the specified color for debugging purposes
+func (p *Polygon) Draw(color *color.RGBA, imd *imdraw.IMDraw, target pixel.Target) {
+	imd.Reset()
+	imd.Color = *color
+	imd.Push(p.Vertices...)
+	imd.Polygon(0)
+}
+
+func (p *Polygon) vertices() []pixel.Vec {
+	return p.Vertices
+}
+
+// convex is implemented by shapes defined by the vertices of a convex polygon
+type convex interface {
+	Shape
+	vertices() []pixel.Vec
+}
+
+// convexMTV returns the minimum translation vector which separates the convex polygon defined by vertices
+// from other, if they collide
+func convexMTV(vertices []pixel.Vec, other Shape) (pixel.Vec, bool) {
+	switch t := other.(type) {
+	case *Box:
+		return polygonsMTV(vertices, rectVertices(t.Rect))
+	case *Circle:
+		return polygonCircleMTV(vertices, t.Circle)
+	case convex:
+		return polygonsMTV(vertices, t.vertices())
+	}
+	return pixel.ZV, false
+}
+
+// sweepPolygon returns the fraction of delta the polygon defined by vertices moves before hitting other
+func sweepPolygon(vertices []pixel.Vec, other Shape, delta pixel.Vec) (float64, pixel.Vec, bool) {
+	switch t := other.(type) {
+	case *Box:
+		return sweepPolygons(vertices, rectVertices(t.Rect), delta)
+	case *Circle:
+		// Same as the circle moving in the opposite direction towards the polygon
+		toi, normal, ok := sweepCirclePolygon(t.Center, delta.Scaled(-1), t.Radius, vertices)
+		return toi, normal.Scaled(-1), ok
+	case convex:
+		return sweepPolygons(vertices, t.vertices(), delta)
+	}
+	return 0, pixel.ZV, false
+}
+
+// rectVertices returns the vertices of r in counter clockwise order
+func rectVertices(r pixel.Rect) []pixel.Vec {
+	return []pixel.Vec{r.Min, pixel.V(r.Max.X, r.Min.Y), r.Max, pixel.V(r.Min.X, r.Max.Y)}
+}
+
+func signedArea(vertices []pixel.Vec) float64 {
+	var area float64
+	for i, v := range vertices {
+		area += v.Cross(vertices[(i+1)%len(vertices)])
+	}
+	return area / 2
+}
+
+// edgeNormal returns the outward normal of the edge which starts at vertex i of a counter clockwise polygon
+func edgeNormal(vertices []pixel.Vec, i int) pixel.Vec {
+	e := vertices[i].To(vertices[(i+1)%len(vertices)])
+	return pixel.V(e.Y, -e.X).Unit()
+}
+
+func project(vertices []pixel.Vec, axis pixel.Vec) (float64, float64) {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range vertices {
+		p := v.Dot(axis)
+		min, max = math.Min(min, p), math.Max(max, p)
+	}
+	return min, max
+}
+
+// minTranslation updates the minimum translation found so far with the one needed along axis
+// to separate the projections [aMin, aMax] and [bMin, bMax], returning false if they do not overlap
+func minTranslation(mtv *pixel.Vec, depth *float64, axis pixel.Vec, aMin, aMax, bMin, bMax float64) bool {
+	back, forth := aMax-bMin, bMax-aMin
+	if back <= tolerance || forth <= tolerance {
+		return false
+	}
+	if back < *depth {
+		*depth = back
+		*mtv = axis.Scaled(-back)
+	}
+	if forth < *depth {
+		*depth = forth
+		*mtv = axis.Scaled(forth)
+	}
+	return true
+}
+
+// polygonsMTV returns the minimum translation vector which separates polygon a from polygon b
+// using the Separating Axis Theorem
+func polygonsMTV(a, b []pixel.Vec) (pixel.Vec, bool) {
+	var mtv pixel.Vec
+	depth := math.Inf(1)
+	for _, vertices := range [2][]pixel.Vec{a, b} {
+		for i := range vertices {
+			axis := edgeNormal(vertices, i)
+			aMin, aMax := project(a, axis)
+			bMin, bMax := project(b, axis)
+			if !minTranslation(&mtv, &depth, axis, aMin, aMax, bMin, bMax) {
+				return pixel.ZV, false
+			}
+		}
+	}
+	return mtv, true
+}
+
+// polygonCircleMTV returns the minimum translation vector which separates polygon a from circle c
+// using the Separating Axis Theorem
+func polygonCircleMTV(a []pixel.Vec, c pixel.Circle) (pixel.Vec, bool) {
+	closest := a[0]
+	for _, v := range a[1:] {
+		if v.To(c.Center).Len() < closest.To(c.Center).Len() {
+			closest = v
+		}
+	}
+	axes := make([]pixel.Vec, 0, len(a)+1)
+	for i := range a {
+		axes = append(axes, edgeNormal(a, i))
+	}
+	if closest != c.Center {
+		axes = append(axes, closest.To(c.Center).Unit())
+	}
+
+	var mtv pixel.Vec
+	depth := math.Inf(1)
+	for _, axis := range axes {
+		aMin, aMax := project(a, axis)
+		center := c.Center.Dot(axis)
+		if !minTranslation(&mtv, &depth, axis, aMin, aMax, center-c.Radius, center+c.Radius) {
+			return pixel.ZV, false
+		}
+	}
+	return mtv, true
+}
+
+// sweepPolygons returns the fraction of d polygon a moves before hitting polygon b.
+// Projections on every axis overlap during a time interval, and both polygons collide
+// when all those intervals do
+func sweepPolygons(a, b []pixel.Vec, d pixel.Vec) (float64, pixel.Vec, bool) {
+	enter, exit := math.Inf(-1), math.Inf(1)
+	var normal pixel.Vec
+
+	for _, vertices := range [2][]pixel.Vec{a, b} {
+		for i := range vertices {
+			axis := edgeNormal(vertices, i)
+			aMin, aMax := project(a, axis)
+			bMin, bMax := project(b, axis)
+			v := d.Dot(axis)
+			if v == 0 {
+				if aMax <= bMin+tolerance || aMin >= bMax-tolerance {
+					return 0, pixel.ZV, false
+				}
+				continue
+			}
+			t1, t2 := (bMin-aMax)/v, (bMax-aMin)/v
+			if t1 > t2 {
+				t1, t2 = t2, t1
+			}
+			if t1 > enter {
+				enter = t1
+				normal = axis.Scaled(-math.Copysign(1, v))
+			}
+			if t2 < exit {
+				exit = t2
+			}
+		}
+	}
+
+	if enter >= exit || enter < -tolerance || enter > 1 || exit <= 0 {
+		return 0, pixel.ZV, false
+	}
+	return math.Max(enter, 0), normal, true
+}
+
+// sweepCirclePolygon returns the fraction of d a circle starting at o moves before touching
+// the counter clockwise polygon defined by vertices
+func sweepCirclePolygon(o, d pixel.Vec, radius float64, vertices []pixel.Vec) (float64, pixel.Vec, bool) {
+	toi, normal, hit := 2.0, pixel.ZV, false
+
+	for i, v := range vertices {
+		// Edges are moved outwards a radius distance, and vertices become circles
+		n := edgeNormal(vertices, i)
+		if speed := d.Dot(n); speed < 0 {
+			t := (v.Add(n.Scaled(radius)).Sub(o)).Dot(n) / speed
+			edge := v.To(vertices[(i+1)%len(vertices)])
+			along := v.To(o.Add(d.Scaled(t))).Dot(edge) / edge.Dot(edge)
+			if t >= -tolerance && t <= 1 && along >= 0 && along <= 1 && t < toi {
+				toi, normal, hit = math.Max(t, 0), n, true
+			}
+		}
+		if t, n, ok := rayCircle(o, d, pixel.C(v, radius)); ok && t < toi {
+			toi, normal, hit = t, n, true
+		}
+	}
+	if !hit {
+		return 0, pixel.ZV, false
+	}
+	return toi, normal, true
+}
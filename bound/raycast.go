@@ -0,0 +1,57 @@
+package bound
+
+import (
+	"github.com/faiface/pixel"
+)
+
+// Hit holds information about a shape hit by a ray
+type Hit struct {
+	Object Shaper
+	Point  pixel.Vec
+	// Normal is the surface normal at the hit point, pointing away from the shape hit
+	Normal   pixel.Vec
+	Distance float64
+}
+
+// Raycast casts a ray from origin towards dir, up to maxDist, and returns the closest shape hit, if any.
+// The ray only hits shapes filter can collide with, and goes through triggers.
+// Shapes which contain origin are ignored, and a ray without direction hits nothing
+func Raycast(origin, dir pixel.Vec, maxDist float64, filter Filter, shapes ...Shaper) (Hit, bool) {
+	hit := Hit{}
+	if dir.Len() == 0 {
+		return hit, false
+	}
+	ray := dir.Unit().Scaled(maxDist)
+	closest := 2.0
+
+	for _, s := range shapes {
+		if f := filterOf(s.Shape()); f.IsTrigger || !filter.CanCollide(f) {
+			continue
+		}
+		t, normal, ok := rayShape(origin, ray, s.Shape())
+		if !ok || t >= closest {
+			continue
+		}
+		closest = t
+		hit = Hit{
+			Object:   s,
+			Point:    origin.Add(ray.Scaled(t)),
+			Normal:   normal,
+			Distance: maxDist * t,
+		}
+	}
+	return hit, closest <= 1
+}
+
+// rayShape returns the fraction of d a point starting at o moves before entering s
+func rayShape(o, d pixel.Vec, s Shape) (float64, pixel.Vec, bool) {
+	switch t := s.(type) {
+	case *Box:
+		return rayRect(o, d, t.Rect)
+	case *Circle:
+		return rayCircle(o, d, t.Circle)
+	case convex:
+		return sweepCirclePolygon(o, d, 0, t.vertices())
+	}
+	return 0, pixel.ZV, false
+}
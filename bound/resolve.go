@@ -0,0 +1,179 @@
+package bound
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+)
+
+// tolerance is the overlap allowed between shapes, so objects resting against others
+// are not considered to collide with them because of floating point errors
+const tolerance = 1e-9
+
+// limiter is implemented by shapes which can be resolved one axis at a time
+type limiter interface {
+	Shape
+	// limit returns the distance the shape can move along axis before touching other, once moved offset.
+	// It returns false if other does not block a movement of d along axis
+	limit(other Shape, offset pixel.Vec, axis int, d float64) (float64, bool)
+}
+
+// resolve moves s along X first and then along Y, stopping at the closest shapes in every axis,
+// so objects slide along the shapes they touch instead of stopping completely.
+// The Y pass is done even without vertical movement, so shapes moved horizontally follow the slopes they stand on
+func resolve(s limiter, delta pixel.Vec, others []Shaper) Solution {
+	sol := Solution{}
+	var limits []float64
+
+	for _, axis := range [2]int{AxisX, AxisY} {
+		d := component(delta, axis)
+		if d == 0 && axis == AxisX {
+			continue
+		}
+		offset := pixel.ZV
+		if axis == AxisY {
+			offset.X = delta.X
+			if sol.CollisionAxis&AxisX != 0 {
+				offset.X = sol.Distance.X
+			}
+		}
+
+		first := len(sol.Contacts)
+		limits = limits[:0]
+		allowed := d
+		for _, other := range others {
+			o := other.Shape()
+			if !blocks(s, o) || passesThrough(s, offset, step(axis, d), o) {
+				continue
+			}
+			var l float64
+			var ok bool
+			if slope, isSlope := o.(*Slope); isSlope {
+				l, ok = slope.ground(Bounds(s), offset, axis, d)
+			} else if d != 0 {
+				l, ok = s.limit(o, offset, axis, d)
+			}
+			if !ok {
+				continue
+			}
+			sol.Contacts = append(sol.Contacts, Contact{Object: other, CollisionAxis: axis})
+			limits = append(limits, l)
+			// Slopes may move shapes further than d, to keep them on their surface
+			if len(limits) == 1 || (d > 0 && l < allowed) || (d <= 0 && l > allowed) {
+				allowed = l
+			}
+		}
+		if len(limits) == 0 {
+			continue
+		}
+
+		// Only the closest shapes are touched once the movement is limited
+		contacts := sol.Contacts[:first]
+		for i, l := range limits {
+			if math.Abs(l-allowed) <= tolerance {
+				contacts = append(contacts, sol.Contacts[first+i])
+			}
+		}
+		sol.Contacts = contacts
+
+		sol.CollisionAxis |= axis
+		if axis == AxisX {
+			sol.Distance.X = allowed
+		} else {
+			sol.Distance.Y = allowed
+		}
+	}
+
+	if len(sol.Contacts) > 0 {
+		sol.Object = sol.Contacts[0].Object
+	}
+
+	moved := delta
+	if sol.CollisionAxis&AxisX != 0 {
+		moved.X = sol.Distance.X
+	}
+	if sol.CollisionAxis&AxisY != 0 {
+		moved.Y = sol.Distance.Y
+	}
+	sol.Triggers = triggers(s, moved, others)
+	return sol
+}
+
+// triggers returns the shapes which s collides with once moved delta, but do not block its movement
+func triggers(s Shape, delta pixel.Vec, others []Shaper) []Shaper {
+	var found []Shaper
+	var moved Shape
+	for _, other := range others {
+		if blocks(s, other.Shape()) || !filterOf(s).CanCollide(filterOf(other.Shape())) {
+			continue
+		}
+		if moved == nil {
+			moved = Transform(s, pixel.IM.Moved(delta))
+		}
+		if moved.Collides(other) {
+			found = append(found, other)
+		}
+	}
+	return found
+}
+
+func component(v pixel.Vec, axis int) float64 {
+	if axis == AxisX {
+		return v.X
+	}
+	return v.Y
+}
+
+func step(axis int, d float64) pixel.Vec {
+	if axis == AxisX {
+		return pixel.V(d, 0)
+	}
+	return pixel.V(0, d)
+}
+
+func otherAxis(axis int) int {
+	if axis == AxisX {
+		return AxisY
+	}
+	return AxisX
+}
+
+// ahead returns true if to is in the direction of a movement of d along axis starting at from
+func ahead(from, to pixel.Vec, axis int, d float64) bool {
+	return (component(to, axis)-component(from, axis))*d > 0
+}
+
+func overlaps(a, b pixel.Rect) bool {
+	return a.Min.X < b.Max.X-tolerance && a.Max.X > b.Min.X+tolerance &&
+		a.Min.Y < b.Max.Y-tolerance && a.Max.Y > b.Min.Y+tolerance
+}
+
+func overlapsCircle(r pixel.Rect, c pixel.Circle) bool {
+	closest := pixel.V(pixel.Clamp(c.Center.X, r.Min.X, r.Max.X), pixel.Clamp(c.Center.Y, r.Min.Y, r.Max.Y))
+	return closest.To(c.Center).Len() < c.Radius-tolerance
+}
+
+// circleExtent returns how far c reaches along axis at the closest point of r in the other axis
+func circleExtent(c pixel.Circle, r pixel.Rect, axis int) float64 {
+	o := otherAxis(axis)
+	center := component(c.Center, o)
+	dist := center - pixel.Clamp(center, component(r.Min, o), component(r.Max, o))
+	return math.Sqrt(math.Max(c.Radius*c.Radius-dist*dist, 0))
+}
+
+// sweptDistance returns the distance between r and the segment which goes from p a distance d along axis
+func sweptDistance(r pixel.Rect, p pixel.Vec, axis int, d float64) float64 {
+	from, to := component(p, axis), component(p, axis)+d
+	if from > to {
+		from, to = to, from
+	}
+	var gap float64
+	if to < component(r.Min, axis) {
+		gap = component(r.Min, axis) - to
+	} else if from > component(r.Max, axis) {
+		gap = from - component(r.Max, axis)
+	}
+	o := otherAxis(axis)
+	center := component(p, o)
+	return math.Hypot(gap, center-pixel.Clamp(center, component(r.Min, o), component(r.Max, o)))
+}
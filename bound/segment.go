@@ -0,0 +1,81 @@
+package bound
+
+import (
+	"image/color"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+)
+
+// Segment is a Line with methods to resolve collisions, useful to model thin walls and platforms
+type Segment struct {
+	pixel.Line
+	Filter
+}
+
+// NewSegment returns a new Segment instance
+func NewSegment(a, b pixel.Vec) *Segment {
+	return &Segment{
+		Line:   pixel.L(a, b),
+		Filter: NewFilter(),
+	}
+}
+
+// Shape returns the Segment instance
+func (s *Segment) Shape() Shape {
+	return s
+}
+
+// Align moves the segment so its center is at pos
+func (s *Segment) Align(pos pixel.Vec) {
+	delta := s.Center().To(pos)
+	s.A = s.A.Add(delta)
+	s.B = s.B.Add(delta)
+}
+
+// Collides returns true if the segment collides with the passed shape
+func (s *Segment) Collides(other Shaper) bool {
+	if !s.CanCollide(filterOf(other.Shape())) {
+		return false
+	}
+	_, ok := s.MTV(other)
+	return ok
+}
+
+// MTV returns the minimum translation vector, which is the shortest movement needed to separate
+// the segment from the passed shape, if they collide
+func (s *Segment) MTV(other Shaper) (pixel.Vec, bool) {
+	return convexMTV(s.vertices(), other.Shape())
+}
+
+// Resolve resolves the movement of the segment against other shapes. See Shape.Resolve
+func (s *Segment) Resolve(delta pixel.Vec, others ...Shaper) Solution {
+	return resolve(s, delta, others)
+}
+
+// Sweep moves the segment along delta against other shapes. See Sweeper
+func (s *Segment) Sweep(delta pixel.Vec, others ...Shaper) Solution {
+	return sweep(s, delta, others)
+}
+
+func (s *Segment) impact(other Shape, delta pixel.Vec) (float64, pixel.Vec, bool) {
+	return sweepPolygon(s.vertices(), other, delta)
+}
+
+func (s *Segment) limit(other Shape, offset pixel.Vec, axis int, d float64) (float64, bool) {
+	toi, _, ok := sweepPolygon([]pixel.Vec{s.A.Add(offset), s.B.Add(offset)}, other, step(axis, d))
+	return toi * d, ok
+}
+
+// Segments are handled as polygons with two vertices, whose edges go in both directions
+func (s *Segment) vertices() []pixel.Vec {
+	return []pixel.Vec{s.A, s.B}
+}
+
+// Draw draws the segment on the passed target with the specified color for debugging purposes
+func (s *Segment) Draw(color *color.RGBA, imd *imdraw.IMDraw, target pixel.Target) {
+	imd.Reset()
+	imd.Color = *color
+	imd.Push(s.A, s.B)
+	imd.Line(1)
+}
@@ -0,0 +1,137 @@
+package bound
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+)
+
+// Slope is a floor whose surface goes straight from A to B, solid down to Bottom.
+// Shapes resolved against a slope walk up and down its surface smoothly, following its height
+// under their bottom center, even when moved only horizontally. Slopes only stop vertical movement,
+// so their sides should touch other shapes. Sweep handles slopes as solid polygons instead,
+// so swept shapes, like bouncing balls, hit their surface rather than following it
+type Slope struct {
+	A      pixel.Vec
+	B      pixel.Vec
+	Bottom float64
+	Filter
+}
+
+// NewSlope returns a new Slope instance, with A being the leftmost end of its surface
+func NewSlope(a, b pixel.Vec, bottom float64) *Slope {
+	if a.X > b.X {
+		a, b = b, a
+	}
+	return &Slope{
+		A:      a,
+		B:      b,
+		Bottom: bottom,
+		Filter: NewFilter(),
+	}
+}
+
+// Shape returns the Slope instance
+func (s *Slope) Shape() Shape {
+	return s
+}
+
+// Height returns the height of the slope surface at x, which is clamped to the slope width
+func (s *Slope) Height(x float64) float64 {
+	if s.B.X == s.A.X {
+		return math.Max(s.A.Y, s.B.Y)
+	}
+	t := pixel.Clamp((x-s.A.X)/(s.B.X-s.A.X), 0, 1)
+	return pixel.Lerp(s.A, s.B, t).Y
+}
+
+// Bounds returns the smallest rect which contains the slope
+func (s *Slope) Bounds() pixel.Rect {
+	return pixel.R(s.A.X, s.Bottom, s.B.X, math.Max(s.A.Y, s.B.Y))
+}
+
+// Align moves the slope so the center of its bounds is at pos
+func (s *Slope) Align(pos pixel.Vec) {
+	delta := s.Bounds().Center().To(pos)
+	s.A = s.A.Add(delta)
+	s.B = s.B.Add(delta)
+	s.Bottom += delta.Y
+}
+
+// Collides returns true if the slope collides with the passed shape
+func (s *Slope) Collides(other Shaper) bool {
+	if !s.CanCollide(filterOf(other.Shape())) {
+		return false
+	}
+	_, ok := convexMTV(s.vertices(), other.Shape())
+	return ok
+}
+
+// Resolve resolves the movement of the slope against other shapes, handling it as a polygon. See Shape.Resolve
+func (s *Slope) Resolve(delta pixel.Vec, others ...Shaper) Solution {
+	return resolve(s, delta, others)
+}
+
+// Sweep moves the slope along delta against other shapes, handling it as a polygon. See Sweeper
+func (s *Slope) Sweep(delta pixel.Vec, others ...Shaper) Solution {
+	return sweep(s, delta, others)
+}
+
+func (s *Slope) impact(other Shape, delta pixel.Vec) (float64, pixel.Vec, bool) {
+	return sweepPolygon(s.vertices(), other, delta)
+}
+
+func (s *Slope) limit(other Shape, offset pixel.Vec, axis int, d float64) (float64, bool) {
+	vertices := s.vertices()
+	for i := range vertices {
+		vertices[i] = vertices[i].Add(offset)
+	}
+	toi, _, ok := sweepPolygon(vertices, other, step(axis, d))
+	return toi * d, ok
+}
+
+// ground returns the distance a shape with bounds r can move along axis once moved offset,
+// so its bottom center does not go under the slope surface. Shapes resting on the surface
+// stick to it while moving down, so they do not bounce when walking down the slope.
+// Shapes which were under the surface are not stopped. d can be 0, for shapes moved only horizontally
+func (s *Slope) ground(r pixel.Rect, offset pixel.Vec, axis int, d float64) (float64, bool) {
+	if axis != AxisY || d > 0 {
+		return 0, false
+	}
+	foot := pixel.V(r.Center().X, r.Min.Y)
+	x := foot.X + offset.X
+	if x < s.A.X || x > s.B.X {
+		return 0, false
+	}
+	surface := s.Height(foot.X)
+	if foot.Y < surface-tolerance {
+		return 0, false
+	}
+	h := s.Height(x)
+	if foot.Y > surface+tolerance && foot.Y+d > h {
+		return 0, false
+	}
+	return h - foot.Y, true
+}
+
+// Draw draws the slope surface on the passed target with the specified color for debugging purposes
+func (s *Slope) Draw(color *color.RGBA, imd *imdraw.IMDraw, target pixel.Target) {
+	imd.Reset()
+	imd.Color = *color
+	imd.Push(s.vertices()...)
+	imd.Polygon(0)
+}
+
+// vertices returns the slope corners in counter clockwise order, leaving out the ends of its surface at its bottom
+func (s *Slope) vertices() []pixel.Vec {
+	vertices := []pixel.Vec{pixel.V(s.A.X, s.Bottom), pixel.V(s.B.X, s.Bottom)}
+	if s.B.Y > s.Bottom {
+		vertices = append(vertices, s.B)
+	}
+	if s.A.Y > s.Bottom {
+		vertices = append(vertices, s.A)
+	}
+	return vertices
+}
@@ -0,0 +1,183 @@
+package bound
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+)
+
+// Bounds returns the smallest rect which contains s
+func Bounds(s Shaper) pixel.Rect {
+	switch t := s.Shape().(type) {
+	case *Box:
+		return t.Rect.Norm()
+	case *Circle:
+		return pixel.R(t.Center.X-t.Radius, t.Center.Y-t.Radius, t.Center.X+t.Radius, t.Center.Y+t.Radius)
+	case *Polygon:
+		return t.Bounds()
+	case *Segment:
+		return t.Line.Bounds()
+	case *Slope:
+		return t.Bounds()
+	}
+	return pixel.Rect{}
+}
+
+type cell struct {
+	x, y int
+}
+
+// cellRange holds the cells covered by a rect, both included
+type cellRange struct {
+	min, max cell
+}
+
+// DefaultCellSize is the size of the cells of spatial hashes created with a size which is not valid
+const DefaultCellSize = 32
+
+// SpatialHash is a broad phase structure which splits space in square cells of the same size,
+// so shapes close to an area can be found without checking all of them.
+// Cells should be a bit bigger than most shapes stored.
+// Shapes are used as map keys, so they must be comparable, like the pointers returned by the shape constructors
+type SpatialHash struct {
+	cellSize float64
+	cells    map[cell][]Shaper
+	shapes   map[Shaper]cellRange
+	// seen avoids returning shapes which cover many cells more than once in a query
+	seen map[Shaper]struct{}
+}
+
+// NewSpatialHash returns a new empty SpatialHash instance with cells of cellSize,
+// or DefaultCellSize if it is not a positive finite number
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	if cellSize <= 0 || math.IsNaN(cellSize) || math.IsInf(cellSize, 1) {
+		cellSize = DefaultCellSize
+	}
+	return &SpatialHash{
+		cellSize: cellSize,
+		cells:    make(map[cell][]Shaper),
+		shapes:   make(map[Shaper]cellRange),
+		seen:     make(map[Shaper]struct{}),
+	}
+}
+
+// Insert adds s to the hash, in all the cells its bounds cover
+func (h *SpatialHash) Insert(s Shaper) {
+	if _, ok := h.shapes[s]; ok {
+		h.Move(s)
+		return
+	}
+	cr := h.cellRange(Bounds(s))
+	h.shapes[s] = cr
+	h.add(s, cr)
+}
+
+// Move updates the cells of s, which must be called every time it is moved or resized
+func (h *SpatialHash) Move(s Shaper) {
+	old, ok := h.shapes[s]
+	if !ok {
+		return
+	}
+	cr := h.cellRange(Bounds(s))
+	if cr == old {
+		return
+	}
+	h.remove(s, old)
+	h.shapes[s] = cr
+	h.add(s, cr)
+}
+
+// Remove takes s out of the hash
+func (h *SpatialHash) Remove(s Shaper) {
+	cr, ok := h.shapes[s]
+	if !ok {
+		return
+	}
+	h.remove(s, cr)
+	delete(h.shapes, s)
+}
+
+// Len returns the number of shapes in the hash
+func (h *SpatialHash) Len() int {
+	return len(h.shapes)
+}
+
+// Query returns the shapes whose cells overlap r, which may collide with any shape inside it
+func (h *SpatialHash) Query(r pixel.Rect) []Shaper {
+	var found []Shaper
+	cr := h.cellRange(r.Norm())
+	for x := cr.min.x; x <= cr.max.x; x++ {
+		for y := cr.min.y; y <= cr.max.y; y++ {
+			for _, s := range h.cells[cell{x, y}] {
+				if _, ok := h.seen[s]; ok {
+					continue
+				}
+				h.seen[s] = struct{}{}
+				found = append(found, s)
+			}
+		}
+	}
+	for _, s := range found {
+		delete(h.seen, s)
+	}
+	return found
+}
+
+// Resolve resolves the movement of s against the shapes in the hash it may collide with. See Shape.Resolve
+func (h *SpatialHash) Resolve(s Shaper, delta pixel.Vec) Solution {
+	return s.Shape().Resolve(delta, h.candidates(s, delta)...)
+}
+
+// Sweep sweeps s against the shapes in the hash it may collide with. See Sweep
+func (h *SpatialHash) Sweep(s Shaper, delta pixel.Vec) Solution {
+	return Sweep(s, delta, h.candidates(s, delta)...)
+}
+
+// candidates returns the shapes in the area covered by s along its movement, but s itself
+func (h *SpatialHash) candidates(s Shaper, delta pixel.Vec) []Shaper {
+	r := Bounds(s)
+	found := h.Query(r.Union(r.Moved(delta)))
+	for i := range found {
+		if found[i] == s {
+			found = append(found[:i], found[i+1:]...)
+			break
+		}
+	}
+	return found
+}
+
+func (h *SpatialHash) cellRange(r pixel.Rect) cellRange {
+	return cellRange{
+		min: cell{int(math.Floor(r.Min.X / h.cellSize)), int(math.Floor(r.Min.Y / h.cellSize))},
+		max: cell{int(math.Floor(r.Max.X / h.cellSize)), int(math.Floor(r.Max.Y / h.cellSize))},
+	}
+}
+
+func (h *SpatialHash) add(s Shaper, cr cellRange) {
+	for x := cr.min.x; x <= cr.max.x; x++ {
+		for y := cr.min.y; y <= cr.max.y; y++ {
+			c := cell{x, y}
+			h.cells[c] = append(h.cells[c], s)
+		}
+	}
+}
+
+func (h *SpatialHash) remove(s Shaper, cr cellRange) {
+	for x := cr.min.x; x <= cr.max.x; x++ {
+		for y := cr.min.y; y <= cr.max.y; y++ {
+			c := cell{x, y}
+			shapes := h.cells[c]
+			for i := range shapes {
+				if shapes[i] == s {
+					shapes = append(shapes[:i], shapes[i+1:]...)
+					break
+				}
+			}
+			if len(shapes) == 0 {
+				delete(h.cells, c)
+				continue
+			}
+			h.cells[c] = shapes
+		}
+	}
+}
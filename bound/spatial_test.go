@@ -0,0 +1,141 @@
+package bound_test
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/bound"
+)
+
+func TestSpatialHash(t *testing.T) {
+	h := bound.NewSpatialHash(10)
+	small := bound.NewBox(pixel.V(1, 1), pixel.V(5, 5))
+	large := bound.NewBox(pixel.V(-15, -15), pixel.V(25, 2))
+	circle := bound.NewCircle(35, 35, 2)
+	slope := mustPolygon(pixel.V(40, 0), pixel.V(60, 0), pixel.V(60, 20))
+	h.Insert(small)
+	h.Insert(large)
+	h.Insert(circle)
+	h.Insert(slope)
+
+	var testValues = []struct {
+		testName string
+		update   func()
+		query    pixel.Rect
+		expected []bound.Shaper
+	}{
+		{"Shapes covering many cells are returned once", func() {}, pixel.R(0, 0, 29, 29), []bound.Shaper{small, large}},
+		{"Shapes in other cells are not returned", func() {}, pixel.R(31, 31, 39, 39), []bound.Shaper{circle}},
+		{"Polygons are stored by their bounds", func() {}, pixel.R(55, 5, 56, 6), []bound.Shaper{slope}},
+		{"Moved shapes are found in their new cells", func() {
+			circle.Align(pixel.V(5, 25))
+			h.Move(circle)
+		}, pixel.R(0, 20, 9, 29), []bound.Shaper{circle}},
+		{"Moved shapes are not found in their old cells", func() {}, pixel.R(31, 31, 39, 39), nil},
+		{"Removed shapes are not found", func() { h.Remove(large) }, pixel.R(-10, -10, 9, 9), []bound.Shaper{small}},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.update()
+			if found := h.Query(tt.query); !reflect.DeepEqual(found, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, found)
+			}
+		})
+	}
+
+	if h.Len() != 3 {
+		t.Errorf("Expected 3 shapes in the hash, got %d", h.Len())
+	}
+}
+
+func TestSpatialHashCellSize(t *testing.T) {
+	for _, cellSize := range []float64{0, -10, math.NaN(), math.Inf(1)} {
+		t.Run(fmt.Sprintf("Cell size %f falls back to default", cellSize), func(t *testing.T) {
+			h := bound.NewSpatialHash(cellSize)
+			box := bound.NewBox(pixel.V(1, 1), pixel.V(5, 5))
+			h.Insert(box)
+			if found := h.Query(pixel.R(0, 0, 10, 10)); len(found) != 1 || found[0] != box {
+				t.Errorf("Expected to find box, got %v", found)
+			}
+			if found := h.Query(pixel.R(bound.DefaultCellSize*2, 0, bound.DefaultCellSize*3, 10)); len(found) != 0 {
+				t.Errorf("Expected not to find shapes in other cells, got %v", found)
+			}
+		})
+	}
+}
+
+func TestSpatialHashResolve(t *testing.T) {
+	h := bound.NewSpatialHash(16)
+	floor := bound.NewBox(pixel.V(0, 0), pixel.V(100, 10))
+	wall := bound.NewBox(pixel.V(60, 10), pixel.V(70, 40))
+	hero := bound.NewBox(pixel.V(40, 10), pixel.V(50, 30))
+	h.Insert(floor)
+	h.Insert(wall)
+	h.Insert(hero)
+
+	sol := h.Resolve(hero, pixel.V(20, -5))
+	expected := hero.Resolve(pixel.V(20, -5), floor, wall)
+	if !reflect.DeepEqual(sol, expected) {
+		t.Errorf("Expected solution %v, got %v", expected, sol)
+	}
+
+	sol = h.Sweep(hero, pixel.V(100, 0))
+	if sol.Object != wall {
+		t.Errorf("Shapes far from the start of the movement must be swept against, got %v", sol.Object)
+	}
+}
+
+// level returns a grid of static boxes and a set of dynamic ones moving over them
+func level(static, dynamic int) ([]bound.Shaper, []*bound.Box) {
+	var tiles []bound.Shaper
+	for i := 0; i < static; i++ {
+		x, y := float64(i%50)*16, float64(i/50)*48
+		tiles = append(tiles, bound.NewBox(pixel.V(x, y), pixel.V(x+16, y+16)))
+	}
+	var actors []*bound.Box
+	for i := 0; i < dynamic; i++ {
+		x, y := float64(i%50)*16, float64(i/50)*48+20
+		actors = append(actors, bound.NewBox(pixel.V(x, y), pixel.V(x+10, y+20)))
+	}
+	return tiles, actors
+}
+
+func BenchmarkResolve(b *testing.B) {
+	for _, size := range [][2]int{{100, 10}, {500, 100}, {2000, 500}} {
+		tiles, actors := level(size[0], size[1])
+
+		b.Run(fmt.Sprintf("linear %d static %d dynamic", size[0], size[1]), func(b *testing.B) {
+			others := make([]bound.Shaper, 0, len(tiles)+len(actors))
+			others = append(others, tiles...)
+			for _, a := range actors {
+				others = append(others, a)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, a := range actors {
+					a.Resolve(pixel.V(1, -5), others...)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("spatial hash %d static %d dynamic", size[0], size[1]), func(b *testing.B) {
+			h := bound.NewSpatialHash(32)
+			for _, t := range tiles {
+				h.Insert(t)
+			}
+			for _, a := range actors {
+				h.Insert(a)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, a := range actors {
+					h.Resolve(a, pixel.V(1, -5))
+					h.Move(a)
+				}
+			}
+		})
+	}
+}
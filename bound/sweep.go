@@ -0,0 +1,186 @@
+package bound
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+)
+
+// Sweeper is implemented by shapes which can be swept along a displacement, as all shapes in this package are
+type Sweeper interface {
+	// Sweep moves the shape along delta and stops it at the first shapes hit on its way,
+	// no matter how thin they are or how long delta is. Distance holds the movement done before the impact
+	Sweep(pixel.Vec, ...Shaper) Solution
+}
+
+// Sweep sweeps s along delta against others. Shapes which do not implement Sweeper are resolved instead,
+// so they can go through thin shapes, with TOI taken from the distance moved and no Normal nor Remaining set
+func Sweep(s Shaper, delta pixel.Vec, others ...Shaper) Solution {
+	shape := s.Shape()
+	if sw, ok := shape.(Sweeper); ok {
+		return sw.Sweep(delta, others...)
+	}
+	sol := shape.Resolve(delta, others...)
+	sol.TOI = 1
+	if sol.Object != nil && delta != pixel.ZV {
+		sol.TOI = sol.Distance.Len() / delta.Len()
+	}
+	return sol
+}
+
+// impacter is implemented by shapes which know when they hit other shapes while moving
+type impacter interface {
+	Shape
+	// impact returns the fraction of delta the shape can move before hitting other,
+	// and the contact normal, pointing away from other. It returns false if other is not hit
+	impact(other Shape, delta pixel.Vec) (float64, pixel.Vec, bool)
+}
+
+// sweep moves s along delta, stopping at the first shapes hit
+func sweep(s impacter, delta pixel.Vec, others []Shaper) Solution {
+	sol := Solution{TOI: 1}
+	var normals []pixel.Vec
+
+	for _, other := range others {
+		if !blocks(s, other.Shape()) || passesThrough(s, pixel.ZV, delta, other.Shape()) {
+			continue
+		}
+		toi, normal, ok := s.impact(other.Shape(), delta)
+		if !ok || toi > sol.TOI+tolerance {
+			continue
+		}
+		if len(normals) == 0 || toi < sol.TOI-tolerance {
+			sol.TOI = toi
+			sol.Contacts = sol.Contacts[:0]
+			normals = normals[:0]
+		}
+		sol.Contacts = append(sol.Contacts, Contact{Object: other, CollisionAxis: normalAxis(normal)})
+		normals = append(normals, normal)
+	}
+
+	sol.Distance = delta.Scaled(sol.TOI)
+	sol.Triggers = sweptTriggers(s, sol.Distance, others)
+	if len(normals) == 0 {
+		sol.Contacts = nil
+		return sol
+	}
+
+	sol.Object = sol.Contacts[0].Object
+	sol.Remaining = delta.Scaled(1 - sol.TOI)
+	for i, normal := range normals {
+		sol.CollisionAxis |= sol.Contacts[i].CollisionAxis
+		sol.Normal = sol.Normal.Add(normal)
+		// Movement towards the shapes hit is removed, so the remaining displacement slides along them
+		if dot := sol.Remaining.Dot(normal); dot < 0 {
+			sol.Remaining = sol.Remaining.Sub(normal.Scaled(dot))
+		}
+	}
+	sol.Normal = sol.Normal.Unit()
+	return sol
+}
+
+// sweptTriggers returns the trigger shapes s touches while moving delta, including the ones it passes through
+func sweptTriggers(s impacter, delta pixel.Vec, others []Shaper) []Shaper {
+	var found []Shaper
+	var moved Shape
+	for _, other := range others {
+		if blocks(s, other.Shape()) || !filterOf(s).CanCollide(filterOf(other.Shape())) {
+			continue
+		}
+		if _, _, ok := s.impact(other.Shape(), delta); ok {
+			found = append(found, other)
+			continue
+		}
+		if moved == nil {
+			moved = Transform(s, pixel.IM.Moved(delta))
+		}
+		if moved.Collides(other) {
+			found = append(found, other)
+		}
+	}
+	return found
+}
+
+// normalAxis returns the axes a contact normal is aligned with
+func normalAxis(normal pixel.Vec) int {
+	axis := AxisNone
+	if math.Abs(normal.X) > tolerance {
+		axis |= AxisX
+	}
+	if math.Abs(normal.Y) > tolerance {
+		axis |= AxisY
+	}
+	return axis
+}
+
+// rayRect returns the fraction of d a point starting at o moves before entering r
+func rayRect(o, d pixel.Vec, r pixel.Rect) (float64, pixel.Vec, bool) {
+	enter, exit := math.Inf(-1), math.Inf(1)
+	var normal pixel.Vec
+
+	for _, axis := range [2]int{AxisX, AxisY} {
+		p, v := component(o, axis), component(d, axis)
+		min, max := component(r.Min, axis), component(r.Max, axis)
+		if v == 0 {
+			if p <= min+tolerance || p >= max-tolerance {
+				return 0, pixel.ZV, false
+			}
+			continue
+		}
+		t1, t2 := (min-p)/v, (max-p)/v
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > enter {
+			enter = t1
+			normal = step(axis, -math.Copysign(1, v))
+		}
+		if t2 < exit {
+			exit = t2
+		}
+	}
+
+	// Shapes already overlapping at the start are not hit, as they can't be reached moving along d
+	if enter >= exit || enter < -tolerance || enter > 1 || exit <= 0 {
+		return 0, pixel.ZV, false
+	}
+	return math.Max(enter, 0), normal, true
+}
+
+// rayCircle returns the fraction of d a point starting at o moves before entering c
+func rayCircle(o, d pixel.Vec, c pixel.Circle) (float64, pixel.Vec, bool) {
+	m := c.Center.To(o)
+	a, b := d.Dot(d), m.Dot(d)
+	if a == 0 || b >= 0 {
+		return 0, pixel.ZV, false
+	}
+	disc := b*b - a*(m.Dot(m)-c.Radius*c.Radius)
+	if disc <= 0 {
+		return 0, pixel.ZV, false
+	}
+	t := (-b - math.Sqrt(disc)) / a
+	if t < -tolerance || t > 1 {
+		return 0, pixel.ZV, false
+	}
+	t = math.Max(t, 0)
+	return t, m.Add(d.Scaled(t)).Unit(), true
+}
+
+// sweepCircleRect returns the fraction of d a circle starting at o moves before touching r
+func sweepCircleRect(o, d pixel.Vec, radius float64, r pixel.Rect) (float64, pixel.Vec, bool) {
+	t, normal, ok := rayRect(o, d, expand(r, pixel.V(radius, radius)))
+	if !ok {
+		return 0, pixel.ZV, false
+	}
+	// The corners of the expanded rect are rounded, so hits on them are checked against circles
+	p := o.Add(d.Scaled(t))
+	corner := pixel.V(pixel.Clamp(p.X, r.Min.X, r.Max.X), pixel.Clamp(p.Y, r.Min.Y, r.Max.Y))
+	if corner.X != p.X && corner.Y != p.Y {
+		return rayCircle(o, d, pixel.C(corner, radius))
+	}
+	return t, normal, true
+}
+
+func expand(r pixel.Rect, v pixel.Vec) pixel.Rect {
+	return pixel.Rect{Min: r.Min.Sub(v), Max: r.Max.Add(v)}
+}
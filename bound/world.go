@@ -0,0 +1,203 @@
+package bound
+
+import (
+	"sort"
+
+	"github.com/faiface/pixel"
+)
+
+// Possible collision event types
+const (
+	// Enter happens the first step two shapes collide
+	Enter = iota
+	// Stay happens every step two shapes keep colliding after entering
+	Stay
+	// Exit happens the first step two shapes which collided do not do it anymore
+	Exit
+)
+
+// Event holds a change in the collision state of a pair of shapes
+type Event struct {
+	Type int
+	A    Shaper
+	B    Shaper
+	// OwnerA and OwnerB are the objects A and B were added with, like the hero or a coin
+	OwnerA interface{}
+	OwnerB interface{}
+}
+
+type body struct {
+	id    int
+	owner interface{}
+}
+
+// pair holds the ids of two colliding shapes, the lowest one first
+type pair struct {
+	a, b int
+}
+
+// contact holds a pair of colliding shapes and their owners, so exits can be reported after removing them
+type contact struct {
+	a, b           Shaper
+	ownerA, ownerB interface{}
+}
+
+// World keeps track of the shapes colliding with each other across steps,
+// so game code can know when an object starts or stops touching another one.
+// Shapes which must not generate events between them, like level tiles, should use
+// categories and masks to avoid colliding
+type World struct {
+	hash     *SpatialHash
+	bodies   map[Shaper]body
+	shapes   []Shaper
+	contacts map[pair]contact
+	nextID   int
+	// OnEnter, OnStay and OnExit, if set, are called for every event of their type in Step
+	OnEnter func(Event)
+	OnStay  func(Event)
+	OnExit  func(Event)
+}
+
+// NewWorld returns a new empty World instance, which uses a spatial hash with cells of cellSize
+// to find colliding shapes
+func NewWorld(cellSize float64) *World {
+	return &World{
+		hash:     NewSpatialHash(cellSize),
+		bodies:   make(map[Shaper]body),
+		contacts: make(map[pair]contact),
+	}
+}
+
+// Add adds s to the world. owner is reachable from the events s is part of.
+// s is used as a map key, so it must be comparable
+func (w *World) Add(s Shaper, owner interface{}) {
+	if b, ok := w.bodies[s]; ok {
+		b.owner = owner
+		w.bodies[s] = b
+		return
+	}
+	w.bodies[s] = body{id: w.nextID, owner: owner}
+	w.nextID++
+	w.shapes = append(w.shapes, s)
+	w.hash.Insert(s)
+}
+
+// Remove takes s out of the world. Collisions s was part of exit in the next step
+func (w *World) Remove(s Shaper) {
+	if _, ok := w.bodies[s]; !ok {
+		return
+	}
+	for i := range w.shapes {
+		if w.shapes[i] == s {
+			w.shapes = append(w.shapes[:i], w.shapes[i+1:]...)
+			break
+		}
+	}
+	w.hash.Remove(s)
+	delete(w.bodies, s)
+}
+
+// Owner returns the owner s was added with
+func (w *World) Owner(s Shaper) interface{} {
+	return w.bodies[s].owner
+}
+
+// Len returns the number of shapes in the world
+func (w *World) Len() int {
+	return len(w.shapes)
+}
+
+// Resolve resolves the movement of s against the shapes in the world. See Shape.Resolve
+func (w *World) Resolve(s Shaper, delta pixel.Vec) Solution {
+	return w.hash.Resolve(s, delta)
+}
+
+// Sweep sweeps s against the shapes in the world. See Sweep
+func (w *World) Sweep(s Shaper, delta pixel.Vec) Solution {
+	return w.hash.Sweep(s, delta)
+}
+
+// Step checks which shapes collide, and returns the events happened since the previous step,
+// exits first and then enters and stays, in the order shapes were added.
+// Shapes must be at their positions for this step when called
+func (w *World) Step() []Event {
+	for _, s := range w.shapes {
+		w.hash.Move(s)
+	}
+
+	current := make(map[pair]contact)
+	var pairs []pair
+	for _, a := range w.shapes {
+		idA := w.bodies[a].id
+		for _, b := range w.hash.Query(Bounds(a)) {
+			idB := w.bodies[b].id
+			if idB <= idA || !a.Shape().Collides(b) {
+				continue
+			}
+			p := pair{idA, idB}
+			current[p] = contact{a, b, w.bodies[a].owner, w.bodies[b].owner}
+			pairs = append(pairs, p)
+		}
+	}
+
+	var exits []pair
+	for p := range w.contacts {
+		if _, ok := current[p]; !ok {
+			exits = append(exits, p)
+		}
+	}
+	sortPairs(exits)
+	sortPairs(pairs)
+
+	events := make([]Event, 0, len(exits)+len(pairs))
+	for _, p := range exits {
+		events = append(events, newEvent(Exit, w.contacts[p]))
+	}
+	for _, p := range pairs {
+		eventType := Enter
+		if _, ok := w.contacts[p]; ok {
+			eventType = Stay
+		}
+		events = append(events, newEvent(eventType, current[p]))
+	}
+	w.contacts = current
+
+	for _, e := range events {
+		w.dispatch(e)
+	}
+	return events
+}
+
+func newEvent(eventType int, c contact) Event {
+	return Event{
+		Type:   eventType,
+		A:      c.a,
+		B:      c.b,
+		OwnerA: c.ownerA,
+		OwnerB: c.ownerB,
+	}
+}
+
+func (w *World) dispatch(e Event) {
+	var callback func(Event)
+	switch e.Type {
+	case Enter:
+		callback = w.OnEnter
+	case Stay:
+		callback = w.OnStay
+	case Exit:
+		callback = w.OnExit
+	}
+	if callback != nil {
+		callback(e)
+	}
+}
+
+func sortPairs(pairs []pair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].a != pairs[j].a {
+			return pairs[i].a < pairs[j].a
+		}
+		return pairs[i].b < pairs[j].b
+	})
+}
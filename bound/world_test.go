@@ -0,0 +1,79 @@
+package bound_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/bound"
+)
+
+func TestWorld(t *testing.T) {
+	hero := bound.NewBox(pixel.V(0, 0), pixel.V(10, 10))
+	coin := bound.NewCircle(20, 5, 2)
+	coin.IsTrigger = true
+	ladder := bound.NewBox(pixel.V(30, 0), pixel.V(35, 40))
+
+	world := bound.NewWorld(16)
+	world.Add(hero, "hero")
+	world.Add(coin, "coin")
+	world.Add(ladder, "ladder")
+
+	var entered []interface{}
+	world.OnEnter = func(e bound.Event) {
+		entered = append(entered, e.OwnerB)
+	}
+
+	var testValues = []struct {
+		testName       string
+		position       pixel.Vec
+		expectedEvents []bound.Event
+	}{
+		{"No events without collisions", pixel.V(5, 5), []bound.Event{}},
+		{"Collision enters", pixel.V(15, 5), []bound.Event{
+			{Type: bound.Enter, A: hero, B: coin, OwnerA: "hero", OwnerB: "coin"},
+		}},
+		{"Collision stays", pixel.V(17, 5), []bound.Event{
+			{Type: bound.Stay, A: hero, B: coin, OwnerA: "hero", OwnerB: "coin"},
+		}},
+		{"Collisions exit before entering others", pixel.V(29, 5), []bound.Event{
+			{Type: bound.Exit, A: hero, B: coin, OwnerA: "hero", OwnerB: "coin"},
+			{Type: bound.Enter, A: hero, B: ladder, OwnerA: "hero", OwnerB: "ladder"},
+		}},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			hero.Align(tt.position)
+			events := world.Step()
+			if !reflect.DeepEqual(events, tt.expectedEvents) {
+				t.Errorf("Expected events %v, got %v", tt.expectedEvents, events)
+			}
+		})
+	}
+
+	t.Run("Callbacks are called", func(t *testing.T) {
+		if !reflect.DeepEqual(entered, []interface{}{"coin", "ladder"}) {
+			t.Errorf("Expected enter callbacks for coin and ladder, got %v", entered)
+		}
+	})
+
+	t.Run("Removed shapes exit", func(t *testing.T) {
+		world.Remove(ladder)
+		events := world.Step()
+		expected := []bound.Event{{Type: bound.Exit, A: hero, B: ladder, OwnerA: "hero", OwnerB: "ladder"}}
+		if !reflect.DeepEqual(events, expected) {
+			t.Errorf("Expected events %v, got %v", expected, events)
+		}
+		if world.Len() != 2 || world.Owner(ladder) != nil {
+			t.Errorf("Expected ladder to be removed")
+		}
+	})
+
+	t.Run("Shapes which cannot collide do not generate events", func(t *testing.T) {
+		hero.Mask = 2
+		world.Add(ladder, "ladder")
+		if events := world.Step(); len(events) != 0 {
+			t.Errorf("Expected no events, got %v", events)
+		}
+	})
+}
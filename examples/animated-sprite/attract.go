@@ -33,7 +33,7 @@ func NewAttract(imd *imdraw.IMDraw) *Attract {
 	a := Attract{
 		txt:   text.New(pixel.V(centerX, centerY), atlas),
 		txtFx: fx.NewBlinking(0.5),
-		fade:  fx.NewFade(black.Mul(pixel.Alpha(0)), 1),
+		fade:  fx.NewFade(black, 1),
 		imd:   imd,
 		exit:  false,
 	}
@@ -49,9 +49,11 @@ func (a *Attract) Loop(w *pixelgl.Window, dt float64) (string, error) {
 	w.Clear(color.Black)
 	a.txtFx.Draw(a.txt.Draw, w, pixel.IM, dt)
 	if a.exit {
-		if a.fade.To(w, a.imd, dt) {
+		a.fade.Update(dt)
+		if a.fade.Done() {
 			return "game", nil
 		}
+		a.fade.Draw(a.imd, w, w.Bounds())
 	}
 	if w.JustPressed(pixelgl.KeySpace) {
 		a.exit = true
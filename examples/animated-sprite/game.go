@@ -6,7 +6,6 @@ import (
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
 	"github.com/faiface/pixel/pixelgl"
-	"github.com/svera/quarter/physic"
 )
 
 type Game struct {
@@ -18,7 +17,7 @@ type Game struct {
 }
 
 func NewGame(canvas *pixelgl.Canvas, imd *imdraw.IMDraw) *Game {
-	h, err := NewHero("hero.json")
+	h, err := NewHero("hero.json", "hero-states.json")
 	if err != nil {
 		panic(err)
 	}
@@ -44,13 +43,13 @@ func (g *Game) Loop(win *pixelgl.Window, dt float64) (string, error) {
 		return "game", nil
 	}
 	g.imd.Clear()
-	delta := g.hero.Displacement(dt)
-	sol := g.hero.boundingShape().Resolve(delta, g.level.Bounds["level1-background"]...)
-
-	g.hero.updatePosition(sol, delta)
+	g.hero.move(dt, g.level.Shapes)
+	if err := g.hero.states.Update(dt); err != nil {
+		return "", err
+	}
 
 	g.level.Draw(g.canvas, &color.RGBA{0, 0, 255, 16}, g.imd)
-	g.hero.Draw(g.canvas, &color.RGBA{255, 0, 0, 16}, g.imd, dt)
+	g.hero.Draw(g.canvas, &color.RGBA{255, 0, 0, 16}, g.imd)
 
 	g.imd.Draw(g.canvas)
 	g.canvas.Draw(win, pixel.IM.Moved(win.Bounds().Center()).Scaled(win.Bounds().Center(), zoom))
@@ -60,14 +59,20 @@ func (g *Game) Loop(win *pixelgl.Window, dt float64) (string, error) {
 
 func (g *Game) readInput(win *pixelgl.Window, dt float64) {
 	if win.JustPressed(pixelgl.KeyUp) {
-		g.hero.Jump(dt)
-	} else if win.Pressed(pixelgl.KeyLeft) {
-		g.hero.Left(dt)
+		g.hero.PressJump()
+	} else if win.JustReleased(pixelgl.KeyUp) {
+		g.hero.ReleaseJump()
+	}
+
+	if win.Pressed(pixelgl.KeyLeft) {
+		g.hero.Left()
 	} else if win.Pressed(pixelgl.KeyRight) {
-		g.hero.Right(dt)
-	} else if !win.Pressed(pixelgl.KeyLeft) && !win.Pressed(pixelgl.KeyRight) && g.hero.Velocity(physic.AxisX) != 0 {
-		g.hero.Decelerate(physic.AxisX, dt)
-	} else if win.JustPressed(pixelgl.KeyP) {
+		g.hero.Right()
+	} else {
+		g.hero.Walk(0)
+	}
+
+	if win.JustPressed(pixelgl.KeyP) {
 		g.paused = !g.paused
 	}
 }
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"bytes"
 	"image/color"
-	"io"
 	"os"
 
 	"github.com/faiface/pixel"
@@ -18,95 +16,89 @@ const (
 	impulse = 64
 )
 
+// defaultBody is used as bounding shape for frames without a body shape
+var defaultBody = bound.NewBox(pixel.V(-4.5, -11), pixel.V(4.5, 11))
+
 type Hero struct {
-	*physic.Physics
+	*physic.Controller
 	*animation.Animation
-	heroBounds map[string][]bound.Shaper
+	states *animation.StateMachine
 }
 
-func NewHero(dataFile string) (*Hero, error) {
+func NewHero(dataFile, statesFile string) (*Hero, error) {
 	r, err := os.Open(dataFile)
 	if err != nil {
 		return nil, err
 	}
-	var buf bytes.Buffer
-	tee := io.TeeReader(r, &buf)
+	defer r.Close()
+	anim, err := animation.Deserialize(r, pixel.V(64, 32))
+	if err != nil {
+		return nil, err
+	}
 
-	anim, err := animation.Deserialize(tee, pixel.V(64, 32))
+	s, err := os.Open(statesFile)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	defer s.Close()
+	graph, err := animation.DeserializeStateGraph(s)
+	if err != nil {
+		return nil, err
 	}
-	anim.SetCurrentAnim("idle")
-	bounds, err := bound.Deserialize(&buf)
+	states, err := graph.New(anim)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Hero{
-		physic.NewPhysics(
+		physic.NewController(
 			physic.Params{
 				MaxVelocity:  [2]float64{50, 100},
 				Acceleration: [2]float64{75, 0},
 				Gravity:      112,
 			},
+			physic.ControllerParams{
+				JumpVelocity: impulse,
+				JumpCutoff:   0.5,
+				CoyoteTime:   0.1,
+				JumpBuffer:   0.1,
+			},
 		),
 		anim,
-		bounds,
+		states,
 	}, nil
 }
 
-func (h *Hero) Jump(dt float64) {
-	if h.CurrentAnim() != "jumping" && h.CurrentAnim() != "falling" {
-		h.SetVelocity(physic.AxisY, impulse)
-	}
-}
-
-func (h *Hero) Left(dt float64) {
+func (h *Hero) Left() {
 	h.Dir = physic.DirectionLeft
-	h.Accelerate(physic.AxisX, h.Dir, dt)
+	h.Walk(h.Dir)
 }
 
-func (h *Hero) Right(dt float64) {
+func (h *Hero) Right() {
 	h.Dir = physic.DirectionRight
-	h.Accelerate(physic.AxisX, h.Dir, dt)
+	h.Walk(h.Dir)
 }
 
-func (h *Hero) Draw(target *pixelgl.Canvas, debug *color.RGBA, imd *imdraw.IMDraw, dt float64) {
-	h.Animation.Draw(target, dt)
+func (h *Hero) Draw(target *pixelgl.Canvas, debug *color.RGBA, imd *imdraw.IMDraw) {
+	h.Animation.Draw(target)
 	h.boundingShape().Draw(debug, imd, target)
 }
 
-func (h *Hero) updatePosition(sol bound.Solution, delta pixel.Vec) {
-	if sol.CollisionAxis == bound.AxisX {
-		h.SetVelocity(physic.AxisX, 0)
-		h.Position = h.Position.Add(pixel.V(sol.Distance.X, delta.Y))
-	} else if sol.CollisionAxis == bound.AxisY {
-		h.SetVelocity(physic.AxisY, 0)
-		h.Position = h.Position.Add(pixel.V(delta.X, sol.Distance.Y))
-	} else if sol.CollisionAxis == bound.AxisBoth {
-		h.SetVelocity(physic.AxisY, 0)
-		h.SetVelocity(physic.AxisX, 0)
-		h.Position = h.Position.Add(sol.Distance)
-	} else {
-		h.Position = h.Position.Add(delta)
-	}
-	h.updateAnim(sol)
+func (h *Hero) move(dt float64, level physic.Resolver) {
+	h.Position = h.Position.Add(h.Controller.Update(dt, h.boundingShape(), level))
+	h.updateAnim()
 }
 
+// boundingShape returns the body shape of the current frame, or a default one if the frame has none
 func (h *Hero) boundingShape() bound.Shape {
-	bb := h.heroBounds[h.CurrentAnim()][h.CurrentFrameNumber()]
-	bb.Shape().Align(h.Position)
-	return bb.Shape()
+	if shapes := h.Shapes("body"); len(shapes) > 0 {
+		return shapes[0]
+	}
+	return h.TransformShape(defaultBody)
 }
 
-func (h *Hero) updateAnim(sol bound.Solution) {
-	if h.Velocity(physic.AxisY) > 0 {
-		h.SetCurrentAnim("jumping")
-	} else if h.Velocity(physic.AxisY) < 0 && sol.CollisionAxis != bound.AxisY {
-		h.SetCurrentAnim("falling")
-	} else if h.Velocity(physic.AxisX) != 0 {
-		h.SetCurrentAnim("running")
-	} else {
-		h.SetCurrentAnim("idle")
-	}
+func (h *Hero) updateAnim() {
+	h.states.SetFloat("vx", h.Velocity(physic.AxisX))
+	h.states.SetFloat("vy", h.Velocity(physic.AxisY))
+	h.states.SetBool("grounded", h.Grounded())
 }
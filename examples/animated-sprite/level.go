@@ -15,6 +15,8 @@ import (
 type Level struct {
 	levels map[string]level.Level
 	Bounds map[string][]bound.Shaper
+	// Shapes holds the bounds of the current level, for fast collision checks
+	Shapes *bound.SpatialHash
 	circle *bound.Circle
 }
 
@@ -34,8 +36,14 @@ func NewLevel(dataFile string) (*Level, error) {
 		return nil, err
 	}
 
+	shapes := bound.NewSpatialHash(32)
+	for _, b := range levelBounds["level1-background"] {
+		shapes.Insert(b)
+	}
+
 	return &Level{
 		Bounds: levelBounds,
+		Shapes: shapes,
 		levels: lvl,
 		circle: bound.NewCircle(0, 40, 18),
 	}, nil
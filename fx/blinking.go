@@ -7,8 +7,11 @@ import (
 // Blinking implements a flashing effect
 type Blinking struct {
 	Frequency float64
-	elapsed   float64
-	show      bool
+	// Times is the number of blinks to do before the effect is over. 0 means blinking forever
+	Times   int
+	elapsed float64
+	show    bool
+	toggles int
 }
 
 // NewBlinking returns a new Blinking instance
@@ -19,13 +22,43 @@ func NewBlinking(freq float64) *Blinking {
 	}
 }
 
+// Draw updates the effect and calls draw if the drawable must be shown
 func (t *Blinking) Draw(draw func(pixel.Target, pixel.Matrix), tgt pixel.Target, matrix pixel.Matrix, dt float64) {
+	t.Update(dt)
+	if t.show {
+		draw(tgt, matrix)
+	}
+}
+
+// Update advances the effect dt seconds
+func (t *Blinking) Update(dt float64) {
+	if t.Done() {
+		return
+	}
 	t.elapsed += dt
 	if t.elapsed > t.Frequency {
 		t.show = !t.show
 		t.elapsed = 0
+		t.toggles++
 	}
-	if t.show {
-		draw(tgt, matrix)
+}
+
+// Apply makes the drawable fully transparent while it is hidden
+func (t *Blinking) Apply(matrix pixel.Matrix, mask pixel.RGBA) (pixel.Matrix, pixel.RGBA) {
+	if !t.show {
+		return matrix, pixel.Alpha(0)
 	}
+	return matrix, mask
+}
+
+// Done returns true when the drawable has blinked Times times
+func (t *Blinking) Done() bool {
+	return t.Times > 0 && t.toggles >= t.Times*2
+}
+
+// Reset sets the effect back to its initial state
+func (t *Blinking) Reset() {
+	t.elapsed = 0
+	t.show = true
+	t.toggles = 0
 }
@@ -0,0 +1,235 @@
+package fx
+
+import (
+	"image/color"
+
+	"github.com/faiface/pixel"
+)
+
+// Effect defines the minimum contract required for effects that can be applied to any drawable
+// and composed with other effects
+type Effect interface {
+	// Update advances the effect dt seconds
+	Update(dt float64)
+	// Apply returns the passed matrix and color mask modified by the effect current state
+	Apply(matrix pixel.Matrix, mask pixel.RGBA) (pixel.Matrix, pixel.RGBA)
+	// Done returns whether the effect is over or not
+	Done() bool
+	// Reset sets the effect back to its initial state, so it can be played again
+	Reset()
+}
+
+// Remainder is implemented by effects which can tell how much of the time passed to their last update
+// was not needed to get them over, so compositions can pass it to the next effect without drifting
+type Remainder interface {
+	Remainder() float64
+}
+
+// remainder returns the time of the last update of e not used, or 0 if e does not tell it
+func remainder(e Effect) float64 {
+	if r, ok := e.(Remainder); ok {
+		return r.Remainder()
+	}
+	return 0
+}
+
+// Drawer is anything that can be drawn on a target using a matrix and a color mask,
+// like pixel.Sprite or pixelgl.Canvas
+type Drawer interface {
+	DrawColorMask(t pixel.Target, matrix pixel.Matrix, mask color.Color)
+}
+
+// Draw draws d on target after applying the passed effect to matrix
+func Draw(e Effect, d Drawer, target pixel.Target, matrix pixel.Matrix) {
+	m, mask := e.Apply(matrix, pixel.Alpha(1))
+	d.DrawColorMask(target, m, mask)
+}
+
+type sequence struct {
+	effects []Effect
+	current int
+}
+
+// Sequence returns an effect that plays the passed effects one after another
+func Sequence(effects ...Effect) Effect {
+	return &sequence{
+		effects: effects,
+	}
+}
+
+// Update passes the time left over when an effect is over to the next one
+func (s *sequence) Update(dt float64) {
+	for !s.Done() {
+		e := s.effects[s.current]
+		e.Update(dt)
+		if !e.Done() {
+			return
+		}
+		s.current++
+		dt = remainder(e)
+	}
+}
+
+// Apply uses the last played effect once the sequence is over, so its final state is kept
+func (s *sequence) Apply(matrix pixel.Matrix, mask pixel.RGBA) (pixel.Matrix, pixel.RGBA) {
+	if len(s.effects) == 0 {
+		return matrix, mask
+	}
+	if s.Done() {
+		return s.effects[len(s.effects)-1].Apply(matrix, mask)
+	}
+	return s.effects[s.current].Apply(matrix, mask)
+}
+
+func (s *sequence) Done() bool {
+	return s.current >= len(s.effects)
+}
+
+func (s *sequence) Remainder() float64 {
+	if !s.Done() || len(s.effects) == 0 {
+		return 0
+	}
+	return remainder(s.effects[len(s.effects)-1])
+}
+
+func (s *sequence) Reset() {
+	s.current = 0
+	for _, e := range s.effects {
+		e.Reset()
+	}
+}
+
+type parallel struct {
+	effects []Effect
+}
+
+// Parallel returns an effect that plays the passed effects at the same time,
+// and which is over when all of them are
+func Parallel(effects ...Effect) Effect {
+	return &parallel{
+		effects: effects,
+	}
+}
+
+func (p *parallel) Update(dt float64) {
+	for _, e := range p.effects {
+		if !e.Done() {
+			e.Update(dt)
+		}
+	}
+}
+
+func (p *parallel) Apply(matrix pixel.Matrix, mask pixel.RGBA) (pixel.Matrix, pixel.RGBA) {
+	for _, e := range p.effects {
+		matrix, mask = e.Apply(matrix, mask)
+	}
+	return matrix, mask
+}
+
+func (p *parallel) Done() bool {
+	for _, e := range p.effects {
+		if !e.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parallel) Reset() {
+	for _, e := range p.effects {
+		e.Reset()
+	}
+}
+
+type repeat struct {
+	effect Effect
+	times  int
+	played int
+}
+
+// Repeat returns an effect that plays the passed one the number of times specified.
+// If times is 0 or less, the effect is repeated forever
+func Repeat(e Effect, times int) Effect {
+	return &repeat{
+		effect: e,
+		times:  times,
+	}
+}
+
+func (r *repeat) Update(dt float64) {
+	if r.Done() {
+		return
+	}
+	r.effect.Update(dt)
+	if r.effect.Done() {
+		r.played++
+		if !r.Done() {
+			r.effect.Reset()
+		}
+	}
+}
+
+func (r *repeat) Apply(matrix pixel.Matrix, mask pixel.RGBA) (pixel.Matrix, pixel.RGBA) {
+	return r.effect.Apply(matrix, mask)
+}
+
+func (r *repeat) Done() bool {
+	return r.times > 0 && r.played >= r.times
+}
+
+func (r *repeat) Reset() {
+	r.played = 0
+	r.effect.Reset()
+}
+
+type delay struct {
+	effect   Effect
+	duration float64
+	elapsed  float64
+}
+
+// Delay returns an effect that waits the passed number of seconds before starting playing e.
+// Drawables are left untouched while waiting
+func Delay(seconds float64, e Effect) Effect {
+	return &delay{
+		effect:   e,
+		duration: seconds,
+	}
+}
+
+func (d *delay) Update(dt float64) {
+	if d.Done() {
+		return
+	}
+	if d.elapsed < d.duration {
+		d.elapsed += dt
+		if d.elapsed < d.duration {
+			return
+		}
+		dt = d.elapsed - d.duration
+	}
+	d.effect.Update(dt)
+}
+
+func (d *delay) Apply(matrix pixel.Matrix, mask pixel.RGBA) (pixel.Matrix, pixel.RGBA) {
+	if d.elapsed < d.duration {
+		return matrix, mask
+	}
+	return d.effect.Apply(matrix, mask)
+}
+
+func (d *delay) Done() bool {
+	return d.elapsed >= d.duration && d.effect.Done()
+}
+
+func (d *delay) Remainder() float64 {
+	if !d.Done() {
+		return 0
+	}
+	return remainder(d.effect)
+}
+
+func (d *delay) Reset() {
+	d.elapsed = 0
+	d.effect.Reset()
+}
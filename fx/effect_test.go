@@ -0,0 +1,146 @@
+package fx_test
+
+import (
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/fx"
+)
+
+func TestBlinking(t *testing.T) {
+	bl := fx.NewBlinking(1)
+	bl.Times = 2
+
+	var testValues = []struct {
+		testName     string
+		dt           float64
+		expectedMask pixel.RGBA
+		expectedDone bool
+	}{
+		{"Drawable is shown before frequency is reached", 0.5, pixel.Alpha(1), false},
+		{"Drawable is hidden after frequency is reached", 0.6, pixel.Alpha(0), false},
+		{"Drawable is shown again", 1.1, pixel.Alpha(1), false},
+		{"Drawable is hidden for the second time", 1.1, pixel.Alpha(0), false},
+		{"Effect is over after blinking the number of times specified", 1.1, pixel.Alpha(1), true},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			bl.Update(tt.dt)
+			if _, mask := bl.Apply(pixel.IM, pixel.Alpha(1)); mask != tt.expectedMask {
+				t.Errorf("Expected mask %v, got %v", tt.expectedMask, mask)
+			}
+			if bl.Done() != tt.expectedDone {
+				t.Errorf("Expected done to be %t, got %t", tt.expectedDone, bl.Done())
+			}
+		})
+	}
+}
+
+func TestSequence(t *testing.T) {
+	blink := fx.NewBlinking(1)
+	blink.Times = 1
+	seq := fx.Sequence(blink, fx.NewFade(pixel.RGB(0, 0, 0), 1))
+
+	t.Run("Sequence plays first effect", func(t *testing.T) {
+		seq.Update(1.1)
+		if _, mask := seq.Apply(pixel.IM, pixel.Alpha(1)); mask != pixel.Alpha(0) {
+			t.Errorf("Expected blinking effect to hide drawable, got mask %v", mask)
+		}
+	})
+
+	t.Run("Sequence plays second effect after first one is over", func(t *testing.T) {
+		seq.Update(1.1)
+		seq.Update(1.1)
+		if !seq.Done() {
+			t.Errorf("Sequence must be over after all its effects are")
+		}
+		if _, mask := seq.Apply(pixel.IM, pixel.Alpha(1)); mask != pixel.Alpha(0) {
+			t.Errorf("Expected last effect final state to be kept, got mask %v", mask)
+		}
+	})
+
+	t.Run("Sequence can be reset", func(t *testing.T) {
+		seq.Reset()
+		if seq.Done() {
+			t.Errorf("Sequence must not be over after being reset")
+		}
+	})
+}
+
+func TestSequenceLeftover(t *testing.T) {
+	first := fx.NewFade(pixel.RGB(0, 0, 0), 1)
+	second := fx.NewFade(pixel.RGB(0, 0, 0), 1)
+	seq := fx.Sequence(first, second)
+
+	seq.Update(1.5)
+	if second.Progress() != 0.5 {
+		t.Errorf("Expected time left over by the first effect to be passed to the second one, got progress %f", second.Progress())
+	}
+	seq.Update(0.75)
+	if !seq.Done() || seq.(fx.Remainder).Remainder() != 0.25 {
+		t.Errorf("Expected sequence to be over with 0.25 seconds left over, got %f", seq.(fx.Remainder).Remainder())
+	}
+}
+
+func TestParallel(t *testing.T) {
+	short := fx.NewBlinking(1)
+	short.Times = 1
+	long := fx.NewBlinking(1)
+	long.Times = 2
+	par := fx.Parallel(short, long)
+
+	par.Update(1.1)
+	par.Update(1.1)
+	if par.Done() {
+		t.Errorf("Parallel must not be over until all its effects are")
+	}
+	par.Update(1.1)
+	par.Update(1.1)
+	if !par.Done() {
+		t.Errorf("Parallel must be over when all its effects are")
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	blink := fx.NewBlinking(1)
+	blink.Times = 1
+	rep := fx.Repeat(blink, 3)
+
+	for i := 0; i < 5; i++ {
+		rep.Update(1.1)
+	}
+	if rep.Done() {
+		t.Errorf("Repeat must not be over before playing the effect the number of times specified")
+	}
+	rep.Update(1.1)
+	if !rep.Done() {
+		t.Errorf("Repeat must be over after playing the effect the number of times specified")
+	}
+}
+
+func TestDelay(t *testing.T) {
+	blink := fx.NewBlinking(1)
+	blink.Times = 1
+	del := fx.Delay(2, blink)
+
+	del.Update(1.5)
+	if _, mask := del.Apply(pixel.IM, pixel.Alpha(1)); mask != pixel.Alpha(1) {
+		t.Errorf("Drawable must not be modified while waiting, got mask %v", mask)
+	}
+	del.Update(1.6)
+	if _, mask := del.Apply(pixel.IM, pixel.Alpha(1)); mask != pixel.Alpha(0) {
+		t.Errorf("Effect must start after delay, got mask %v", mask)
+	}
+}
+
+func TestDelayStopsUpdatingFinishedEffects(t *testing.T) {
+	fade := fx.NewFade(pixel.RGB(0, 0, 0), 1)
+	del := fx.Delay(1, fade)
+
+	del.Update(1.5)
+	del.Update(1)
+	del.Update(1)
+	if !del.Done() || fade.Remainder() != 0.5 {
+		t.Errorf("Expected effect not to be updated once over, got %f seconds left over", fade.Remainder())
+	}
+}
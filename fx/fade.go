@@ -0,0 +1,98 @@
+package fx
+
+import (
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+)
+
+// Fade modes
+const (
+	// FadeOut fades the scene out to the fade color
+	FadeOut = iota
+	// FadeIn fades the scene in from the fade color
+	FadeIn
+)
+
+// Fade implements a fading effect from or to a color, or between two drawables
+type Fade struct {
+	// Color is the color the scene fades to or from. Its alpha value is ignored
+	Color    pixel.RGBA
+	Mode     int
+	elapsed  float64
+	duration float64
+}
+
+// NewFade returns a new Fade instance which fades out to color in duration seconds
+func NewFade(color pixel.RGBA, duration float64) *Fade {
+	color.A = 1
+	return &Fade{
+		Color:    color,
+		Mode:     FadeOut,
+		duration: duration,
+	}
+}
+
+// NewFadeIn returns a new Fade instance which fades in from color in duration seconds
+func NewFadeIn(color pixel.RGBA, duration float64) *Fade {
+	f := NewFade(color, duration)
+	f.Mode = FadeIn
+	return f
+}
+
+// Update advances the fade dt seconds
+func (t *Fade) Update(dt float64) {
+	t.elapsed += dt
+}
+
+// Progress returns how much of the fade has been played, being 0 its start and 1 its end
+func (t *Fade) Progress() float64 {
+	if t.duration <= 0 {
+		return 1
+	}
+	return pixel.Clamp(t.elapsed/t.duration, 0, 1)
+}
+
+// Alpha returns the current opacity of the fade color
+func (t *Fade) Alpha() float64 {
+	if t.Mode == FadeIn {
+		return 1 - t.Progress()
+	}
+	return t.Progress()
+}
+
+// Draw draws the fade color with its current opacity on rect
+func (t *Fade) Draw(imd *imdraw.IMDraw, target pixel.Target, rect pixel.Rect) {
+	imd.Color = t.Color.Scaled(t.Alpha())
+	imd.Push(rect.Min, rect.Max)
+	imd.Rectangle(0)
+	imd.Draw(target)
+}
+
+// CrossFade draws from and then to over it, making the latter more opaque as the fade progresses
+func (t *Fade) CrossFade(target pixel.Target, from, to Drawer, matrix pixel.Matrix) {
+	from.DrawColorMask(target, matrix, pixel.Alpha(1))
+	to.DrawColorMask(target, matrix, pixel.Alpha(t.Progress()))
+}
+
+// Apply makes the drawable less opaque as the fade color becomes more opaque
+func (t *Fade) Apply(matrix pixel.Matrix, mask pixel.RGBA) (pixel.Matrix, pixel.RGBA) {
+	return matrix, mask.Scaled(1 - t.Alpha())
+}
+
+// Done returns true when the fade duration has passed
+func (t *Fade) Done() bool {
+	return t.elapsed >= t.duration
+}
+
+// Remainder returns the time of the last update not needed to get the fade over
+func (t *Fade) Remainder() float64 {
+	if t.elapsed <= t.duration {
+		return 0
+	}
+	return t.elapsed - t.duration
+}
+
+// Reset sets the fade back to its initial state, so it can be played again
+func (t *Fade) Reset() {
+	t.elapsed = 0
+}
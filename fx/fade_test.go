@@ -0,0 +1,46 @@
+package fx_test
+
+import (
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/fx"
+)
+
+func TestFade(t *testing.T) {
+	var testValues = []struct {
+		testName      string
+		fade          *fx.Fade
+		dt            float64
+		expectedAlpha float64
+		expectedDone  bool
+	}{
+		{"Fade out starts transparent", fx.NewFade(pixel.RGB(0, 0, 0), 2), 0, 0, false},
+		{"Fade out in progress", fx.NewFade(pixel.RGB(0, 0, 0), 2), 0.5, 0.25, false},
+		{"Fade out reaches exact opacity at completion", fx.NewFade(pixel.RGB(0, 0, 0), 2), 2, 1, true},
+		{"Fade out does not go beyond full opacity", fx.NewFade(pixel.RGB(0, 0, 0), 2), 3, 1, true},
+		{"Fade in starts opaque", fx.NewFadeIn(pixel.RGB(0, 0, 0), 2), 0, 1, false},
+		{"Fade in in progress", fx.NewFadeIn(pixel.RGB(0, 0, 0), 2), 0.5, 0.75, false},
+		{"Fade in reaches exact transparency at completion", fx.NewFadeIn(pixel.RGB(0, 0, 0), 2), 2, 0, true},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.fade.Update(tt.dt)
+			if tt.fade.Alpha() != tt.expectedAlpha {
+				t.Errorf("Expected alpha %f, got %f", tt.expectedAlpha, tt.fade.Alpha())
+			}
+			if tt.fade.Done() != tt.expectedDone {
+				t.Errorf("Expected done to be %t, got %t", tt.expectedDone, tt.fade.Done())
+			}
+		})
+	}
+}
+
+func TestFadeReset(t *testing.T) {
+	fade := fx.NewFade(pixel.RGB(0, 0, 0), 1)
+	fade.Update(1)
+	fade.Reset()
+	if fade.Done() || fade.Alpha() != 0 {
+		t.Errorf("Fade must be back to its initial state after being reset")
+	}
+}
@@ -1,44 +1,18 @@
 package fx
 
 import (
-	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
 	"github.com/faiface/pixel/pixelgl"
 )
 
-type Fade struct {
-	Color    pixel.RGBA
-	elapsed  float64
-	total    float64
-	duration float64
-	step     float64
-}
-
-func NewFade(color pixel.RGBA, duration float64) *Fade {
-	color.A = 0
-	return &Fade{
-		Color:    color,
-		step:     duration / 1000,
-		duration: duration,
-	}
-}
-
+// To advances the fade dt seconds and draws it over the whole window, returning true once it is done.
+//
+// Deprecated: use Update, Draw and Done instead, which work with any target
 func (t *Fade) To(win *pixelgl.Window, imd *imdraw.IMDraw, dt float64) bool {
-	t.elapsed += dt
-	t.total += dt
-	if t.total > t.duration {
+	t.Update(dt)
+	if t.Done() {
 		return true
 	}
-	if t.elapsed > t.step {
-		t.elapsed = 0
-		t.Color.A += dt / t.duration
-	}
-	imd.Color = t.Color
-	imd.Push(
-		pixel.ZV,
-		pixel.V(win.Bounds().W(), win.Bounds().H()),
-	)
-	imd.Rectangle(0)
-	imd.Draw(win)
+	t.Draw(imd, win, win.Bounds())
 	return false
 }
@@ -0,0 +1,128 @@
+package fx
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/faiface/pixel"
+)
+
+// Recolorable is implemented by anything whose sprites are taken from a single picture,
+// like animation sheets or level tilesets
+type Recolorable interface {
+	Picture() pixel.Picture
+	SetPicture(pixel.Picture)
+}
+
+// Remap returns a copy of pic where every color found as a key in colors is replaced by its value.
+// Colors are compared as stored in the picture, which means alpha premultiplied
+func Remap(pic pixel.Picture, colors map[color.RGBA]color.RGBA) *pixel.PictureData {
+	pd := clonePictureData(pic)
+	for i, c := range pd.Pix {
+		if newColor, ok := colors[c]; ok {
+			pd.Pix[i] = newColor
+		}
+	}
+	return pd
+}
+
+// Variants returns a recolored copy of pic for every palette map passed, identified by the same key
+func Variants(pic pixel.Picture, palettes map[string]map[color.RGBA]color.RGBA) map[string]*pixel.PictureData {
+	variants := make(map[string]*pixel.PictureData, len(palettes))
+	for name, colors := range palettes {
+		variants[name] = Remap(pic, colors)
+	}
+	return variants
+}
+
+// Silhouette returns a copy of pic where every non transparent pixel is painted with c, keeping its alpha,
+// which is useful to flash sprites when hit
+func Silhouette(pic pixel.Picture, c color.RGBA) *pixel.PictureData {
+	pd := clonePictureData(pic)
+	for i, p := range pd.Pix {
+		if p.A == 0 {
+			continue
+		}
+		pd.Pix[i] = color.RGBA{
+			R: uint8(uint16(c.R) * uint16(p.A) / 255),
+			G: uint8(uint16(c.G) * uint16(p.A) / 255),
+			B: uint8(uint16(c.B) * uint16(p.A) / 255),
+			A: p.A,
+		}
+	}
+	return pd
+}
+
+func clonePictureData(pic pixel.Picture) *pixel.PictureData {
+	src := pixel.PictureDataFromPicture(pic)
+	pd := &pixel.PictureData{
+		Pix:    make([]color.RGBA, len(src.Pix)),
+		Stride: src.Stride,
+		Rect:   src.Rect,
+	}
+	copy(pd.Pix, src.Pix)
+	return pd
+}
+
+// ColorCycle rotates a range of palette colors over time, like classic arcade games did to animate
+// water or fire without redrawing sprites. All the recolored pictures needed are generated on creation
+type ColorCycle struct {
+	// Frequency is the number of seconds between every color rotation
+	Frequency float64
+	pictures  []*pixel.PictureData
+	targets   []Recolorable
+	current   int
+	elapsed   float64
+}
+
+// Returned errors
+const (
+	ErrorCycleRangeNotValid = "Color cycle range from %d to %d is not valid for a palette of %d colors"
+)
+
+// NewColorCycle returns a new ColorCycle instance which rotates palette colors from index first to last,
+// both inclusive, every freq seconds. An error is returned if the range is empty or out of the palette
+func NewColorCycle(pic pixel.Picture, palette []color.RGBA, first, last int, freq float64) (*ColorCycle, error) {
+	if first < 0 || last >= len(palette) || first > last {
+		return nil, fmt.Errorf(ErrorCycleRangeNotValid, first, last, len(palette))
+	}
+	cycle := palette[first : last+1]
+	c := &ColorCycle{
+		Frequency: freq,
+		pictures:  make([]*pixel.PictureData, len(cycle)),
+	}
+	for step := range cycle {
+		colors := make(map[color.RGBA]color.RGBA, len(cycle))
+		for i, col := range cycle {
+			colors[col] = cycle[(i+step)%len(cycle)]
+		}
+		c.pictures[step] = Remap(pic, colors)
+	}
+	return c, nil
+}
+
+// Attach makes the passed recolorables use the cycle current picture from now on
+func (c *ColorCycle) Attach(targets ...Recolorable) {
+	for _, t := range targets {
+		t.SetPicture(c.Picture())
+	}
+	c.targets = append(c.targets, targets...)
+}
+
+// Update advances the cycle dt seconds, updating attached recolorables when colors rotate
+func (c *ColorCycle) Update(dt float64) {
+	c.elapsed += dt
+	if c.elapsed <= c.Frequency {
+		return
+	}
+	c.elapsed = 0
+	c.current = (c.current + 1) % len(c.pictures)
+	for _, t := range c.targets {
+		t.SetPicture(c.Picture())
+	}
+}
+
+// Picture returns the picture with the colors of the current cycle step
+func (c *ColorCycle) Picture() pixel.Picture {
+	return c.pictures[c.current]
+}
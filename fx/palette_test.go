@@ -0,0 +1,116 @@
+package fx_test
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/fx"
+)
+
+var (
+	red   = color.RGBA{255, 0, 0, 255}
+	green = color.RGBA{0, 255, 0, 255}
+	blue  = color.RGBA{0, 0, 255, 255}
+)
+
+func testPicture(colors ...color.RGBA) *pixel.PictureData {
+	pd := pixel.MakePictureData(pixel.R(0, 0, float64(len(colors)), 1))
+	copy(pd.Pix, colors)
+	return pd
+}
+
+type recolorable struct {
+	pic pixel.Picture
+}
+
+func (r *recolorable) Picture() pixel.Picture {
+	return r.pic
+}
+
+func (r *recolorable) SetPicture(pic pixel.Picture) {
+	r.pic = pic
+}
+
+func TestRemap(t *testing.T) {
+	pic := testPicture(red, green, blue)
+	remapped := fx.Remap(pic, map[color.RGBA]color.RGBA{red: blue})
+	expected := []color.RGBA{blue, green, blue}
+	for i := range expected {
+		if remapped.Pix[i] != expected[i] {
+			t.Errorf("Expected pixel %d to be %v, got %v", i, expected[i], remapped.Pix[i])
+		}
+	}
+	if pic.Pix[0] != red {
+		t.Errorf("Original picture must not be modified")
+	}
+}
+
+func TestSilhouette(t *testing.T) {
+	transparent := color.RGBA{}
+	pic := testPicture(red, transparent)
+	sil := fx.Silhouette(pic, color.RGBA{255, 255, 255, 255})
+	if sil.Pix[0] != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("Expected opaque pixel to be white, got %v", sil.Pix[0])
+	}
+	if sil.Pix[1] != transparent {
+		t.Errorf("Expected transparent pixel to be kept, got %v", sil.Pix[1])
+	}
+}
+
+func TestColorCycle(t *testing.T) {
+	pic := testPicture(red, green, blue)
+	palette := []color.RGBA{red, green, blue}
+	cycle, err := fx.NewColorCycle(pic, palette, 0, 1, 1)
+	if err != nil {
+		t.Fatalf("Valid color cycle is not created: %s", err)
+	}
+	target := &recolorable{}
+	cycle.Attach(target)
+
+	var testValues = []struct {
+		testName      string
+		dt            float64
+		expectedFirst color.RGBA
+	}{
+		{"Colors are not rotated before frequency is reached", 0.5, red},
+		{"Colors in range are rotated", 0.6, green},
+		{"Colors go back to the original ones after a whole cycle", 1.1, red},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			cycle.Update(tt.dt)
+			pd := target.Picture().(*pixel.PictureData)
+			if pd.Pix[0] != tt.expectedFirst {
+				t.Errorf("Expected first pixel to be %v, got %v", tt.expectedFirst, pd.Pix[0])
+			}
+			if pd.Pix[2] != blue {
+				t.Errorf("Colors out of range must not be rotated, got %v", pd.Pix[2])
+			}
+		})
+	}
+}
+
+func TestColorCycleRange(t *testing.T) {
+	pic := testPicture(red, green, blue)
+	palette := []color.RGBA{red, green, blue}
+
+	var testValues = []struct {
+		testName    string
+		first, last int
+	}{
+		{"First index must not be negative", -1, 1},
+		{"Last index must be in the palette", 1, 3},
+		{"First index must not be greater than last one", 2, 1},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			_, err := fx.NewColorCycle(pic, palette, tt.first, tt.last, 1)
+			expected := fmt.Sprintf(fx.ErrorCycleRangeNotValid, tt.first, tt.last, len(palette))
+			if err == nil || err.Error() != expected {
+				t.Errorf("Expected error \"%s\", got \"%v\"", expected, err)
+			}
+		})
+	}
+}
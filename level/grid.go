@@ -33,3 +33,19 @@ func (g *Grid) ToPixels(coords pixel.Vec) pixel.Vec {
 		(coords.Y*g.TileHeight)+g.TileHeight/2,
 	)
 }
+
+// Picture returns the picture grid assets are taken from
+func (g *Grid) Picture() pixel.Picture {
+	if len(g.Assets) == 0 {
+		return nil
+	}
+	return g.Assets[0].Picture()
+}
+
+// SetPicture makes all grid assets to be taken from pic, keeping their bounds.
+// This is useful to use a recolored version of the tileset
+func (g *Grid) SetPicture(pic pixel.Picture) {
+	for _, asset := range g.Assets {
+		asset.Set(pic, asset.Frame())
+	}
+}
@@ -0,0 +1,72 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter"
+)
+
+// Returned errors
+const (
+	ErrorVersionNotSupported = "Version \"%s\" not supported"
+	ErrorNoEmitters          = "File must have at least one emitter declared, none found"
+	ErrorShapeNotSupported   = "Emitter shape \"%s\" is not supported"
+)
+
+// EmittersFile defines the structure of a disk file containing emitter presets
+type EmittersFile struct {
+	Version  string
+	Emitters map[string]struct {
+		Config
+		Sprite struct {
+			Path  string
+			Frame pixel.Rect
+		}
+	}
+}
+
+// Deserialize validates an emitters file and returns its presets, ready to be passed to NewEmitter
+func Deserialize(r io.Reader) (map[string]Config, error) {
+	data := &EmittersFile{}
+	err := json.NewDecoder(r).Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Version != "1" {
+		return nil, fmt.Errorf(ErrorVersionNotSupported, data.Version)
+	}
+
+	if len(data.Emitters) == 0 {
+		return nil, fmt.Errorf(ErrorNoEmitters)
+	}
+
+	configs := make(map[string]Config, len(data.Emitters))
+	pictures := make(map[string]pixel.Picture)
+	for name, em := range data.Emitters {
+		cfg := em.Config
+		switch cfg.Shape {
+		case "", ShapePoint, ShapeLine, ShapeBox, ShapeCircle:
+		default:
+			return nil, fmt.Errorf(ErrorShapeNotSupported, cfg.Shape)
+		}
+		if path := strings.TrimSpace(em.Sprite.Path); path != "" {
+			// Emitters sharing the same image file share the same picture
+			pic, ok := pictures[path]
+			if !ok {
+				pic, err = quarter.LoadPicture(path)
+				if err != nil {
+					return nil, err
+				}
+				pictures[path] = pic
+			}
+			cfg.Sprite = pixel.NewSprite(pic, em.Sprite.Frame)
+		}
+		configs[name] = cfg
+	}
+	return configs, nil
+}
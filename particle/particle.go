@@ -0,0 +1,246 @@
+// Package particle implements particle emitters for effects such as explosions, dust or sparks.
+package particle
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/svera/quarter/physic"
+)
+
+// Emitter shapes, which define where new particles are spawned
+const (
+	// ShapePoint spawns particles at emitter position
+	ShapePoint = "point"
+	// ShapeLine spawns particles along a segment centered at emitter position, going from -Size/2 to Size/2
+	ShapeLine = "line"
+	// ShapeBox spawns particles inside a box of Size dimensions centered at emitter position
+	ShapeBox = "box"
+	// ShapeCircle spawns particles inside a circle of Radius centered at emitter position
+	ShapeCircle = "circle"
+)
+
+// Curve defines a value that changes over the life of a particle, linearly interpolating between
+// evenly spaced points. An empty curve always returns 1
+type Curve []float64
+
+// At returns the curve value at t, being 0 the birth of the particle and 1 its death
+func (c Curve) At(t float64) float64 {
+	if len(c) == 0 {
+		return 1
+	}
+	i, frac := curveIndex(len(c), t)
+	if i == len(c)-1 {
+		return c[i]
+	}
+	return c[i] + (c[i+1]-c[i])*frac
+}
+
+// ColorCurve defines a color that changes over the life of a particle, linearly interpolating between
+// evenly spaced colors. An empty curve always returns white
+type ColorCurve []pixel.RGBA
+
+// At returns the curve color at t, being 0 the birth of the particle and 1 its death
+func (c ColorCurve) At(t float64) pixel.RGBA {
+	if len(c) == 0 {
+		return pixel.Alpha(1)
+	}
+	i, frac := curveIndex(len(c), t)
+	if i == len(c)-1 {
+		return c[i]
+	}
+	return c[i].Add(c[i+1].Sub(c[i]).Scaled(frac))
+}
+
+func curveIndex(length int, t float64) (int, float64) {
+	pos := pixel.Clamp(t, 0, 1) * float64(length-1)
+	i := int(pos)
+	return i, pos - float64(i)
+}
+
+// Config defines how an emitter spawns particles and how these behave
+type Config struct {
+	Shape string
+	// Size is the direction and length of line shapes, or the dimensions of box ones
+	Size pixel.Vec
+	// Radius is the radius of circle shapes
+	Radius float64
+	// Rate is the number of particles spawned per second. 0 means particles are only spawned through bursts
+	Rate float64
+	// MaxParticles is the number of particles allocated by the emitter, which cannot be exceeded
+	MaxParticles int `json:"max_particles"`
+	// Lifetime is the minimum and maximum number of seconds a particle lives
+	Lifetime [2]float64
+	// Speed is the minimum and maximum initial speed of a particle
+	Speed [2]float64
+	// Angle is the minimum and maximum initial direction of a particle in radians
+	Angle [2]float64
+	// Physics uses the same semantics as in physic package: Gravity, or GravityVector if set, pulls particles,
+	// Acceleration increases their speed in the direction they are moving and MaxVelocity, if not zero, limits it.
+	// Drag and TerminalVelocity slow them down as well. Mass is ignored, as no forces act on particles
+	Physics physic.Params
+	// ParticleRadius is the radius of particles drawn when no Sprite is used
+	ParticleRadius float64 `json:"particle_radius"`
+	Color          ColorCurve
+	Scale          Curve
+	Alpha          Curve
+	// Sprite is drawn for every particle if set. Otherwise, particles are drawn as circles
+	Sprite *pixel.Sprite `json:"-"`
+}
+
+type particle struct {
+	position pixel.Vec
+	velocity pixel.Vec
+	age      float64
+	life     float64
+}
+
+// Emitter spawns, updates and draws particles
+type Emitter struct {
+	Config
+	Position pixel.Vec
+	// Emitting defines whether particles are spawned continuously at Rate or not
+	Emitting    bool
+	particles   []particle
+	alive       int
+	accumulated float64
+	imd         *imdraw.IMDraw
+}
+
+// NewEmitter returns a new Emitter instance placed at pos, with all its particles already allocated
+func NewEmitter(cfg Config, pos pixel.Vec) *Emitter {
+	return &Emitter{
+		Config:    cfg,
+		Position:  pos,
+		Emitting:  true,
+		particles: make([]particle, cfg.MaxParticles),
+		imd:       imdraw.New(nil),
+	}
+}
+
+// Burst spawns n particles at once, as long as there is room for them
+func (e *Emitter) Burst(n int) {
+	for i := 0; i < n && e.alive < len(e.particles); i++ {
+		e.spawn(&e.particles[e.alive])
+		e.alive++
+	}
+}
+
+func (e *Emitter) spawn(p *particle) {
+	p.position = e.Position.Add(e.spawnOffset())
+	p.velocity = pixel.Unit(randRange(e.Angle)).Scaled(randRange(e.Speed))
+	p.age = 0
+	p.life = randRange(e.Lifetime)
+}
+
+func (e *Emitter) spawnOffset() pixel.Vec {
+	switch e.Shape {
+	case ShapeLine:
+		return e.Size.Scaled(rand.Float64() - 0.5)
+	case ShapeBox:
+		return pixel.V((rand.Float64()-0.5)*e.Size.X, (rand.Float64()-0.5)*e.Size.Y)
+	case ShapeCircle:
+		// Square root is needed to distribute particles evenly over the circle surface
+		return pixel.Unit(rand.Float64() * 2 * math.Pi).Scaled(e.Radius * math.Sqrt(rand.Float64()))
+	}
+	return pixel.ZV
+}
+
+func randRange(r [2]float64) float64 {
+	return r[0] + (r[1]-r[0])*rand.Float64()
+}
+
+// Update spawns new particles if needed and moves the living ones dt seconds, removing those whose life is over
+func (e *Emitter) Update(dt float64) {
+	if e.Emitting && e.Rate > 0 {
+		e.accumulated += e.Rate * dt
+		n := int(e.accumulated)
+		e.accumulated -= float64(n)
+		e.Burst(n)
+	}
+
+	for i := 0; i < e.alive; {
+		p := &e.particles[i]
+		p.age += dt
+		if p.age >= p.life {
+			// Dead particles are replaced by the last living one, so living particles are always contiguous
+			e.alive--
+			e.particles[i] = e.particles[e.alive]
+			continue
+		}
+		p.velocity.X = e.accelerate(p.velocity.X, e.Physics.Acceleration[physic.AxisX], e.Physics.MaxVelocity[physic.AxisX], dt)
+		p.velocity.Y = e.accelerate(p.velocity.Y, e.Physics.Acceleration[physic.AxisY], e.Physics.MaxVelocity[physic.AxisY], dt)
+		p.velocity = e.pull(p.velocity, dt)
+		p.position = p.position.Add(p.velocity.Scaled(dt))
+		i++
+	}
+}
+
+func (e *Emitter) accelerate(velocity, acceleration, max, dt float64) float64 {
+	if velocity > 0 {
+		velocity += acceleration * dt
+	} else if velocity < 0 {
+		velocity -= acceleration * dt
+	}
+	if max != 0 && math.Abs(velocity) > math.Abs(max) {
+		return math.Copysign(max, velocity)
+	}
+	return velocity
+}
+
+// pull applies gravity, drag and terminal velocity to velocity after dt seconds, as physic.Physics does
+func (e *Emitter) pull(velocity pixel.Vec, dt float64) pixel.Vec {
+	gravity := e.Physics.GravityVector
+	if gravity == pixel.ZV {
+		gravity = pixel.V(0, -e.Physics.Gravity)
+	}
+	velocity = velocity.Add(gravity.Scaled(dt))
+
+	velocity.X -= velocity.X * math.Min(e.Physics.Drag.X*dt, 1)
+	velocity.Y -= velocity.Y * math.Min(e.Physics.Drag.Y*dt, 1)
+
+	if e.Physics.TerminalVelocity > 0 && gravity != pixel.ZV {
+		down := gravity.Unit()
+		if speed := velocity.Dot(down); speed > e.Physics.TerminalVelocity {
+			velocity = velocity.Sub(down.Scaled(speed - e.Physics.TerminalVelocity))
+		}
+	}
+	return velocity
+}
+
+// Draw draws all living particles on target. Passing a pixel.Batch which uses
+// the sprite picture as target is advised when using sprites
+func (e *Emitter) Draw(target pixel.Target) {
+	if e.Sprite != nil {
+		for i := 0; i < e.alive; i++ {
+			p := &e.particles[i]
+			t := p.age / p.life
+			m := pixel.IM.Scaled(pixel.ZV, e.Scale.At(t)).Moved(p.position)
+			e.Sprite.DrawColorMask(target, m, e.Color.At(t).Scaled(e.Alpha.At(t)))
+		}
+		return
+	}
+
+	e.imd.Clear()
+	for i := 0; i < e.alive; i++ {
+		p := &e.particles[i]
+		t := p.age / p.life
+		e.imd.Color = e.Color.At(t).Scaled(e.Alpha.At(t))
+		e.imd.Push(p.position)
+		e.imd.Circle(e.ParticleRadius*e.Scale.At(t), 0)
+	}
+	e.imd.Draw(target)
+}
+
+// Len returns the number of living particles
+func (e *Emitter) Len() int {
+	return e.alive
+}
+
+// Clear removes all living particles
+func (e *Emitter) Clear() {
+	e.alive = 0
+	e.accumulated = 0
+}
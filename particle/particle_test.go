@@ -0,0 +1,141 @@
+package particle_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/particle"
+	"github.com/svera/quarter/physic"
+)
+
+func TestBurst(t *testing.T) {
+	em := particle.NewEmitter(particle.Config{
+		MaxParticles: 10,
+		Lifetime:     [2]float64{1, 1},
+	}, pixel.ZV)
+	em.Emitting = false
+
+	var testValues = []struct {
+		testName    string
+		burst       int
+		dt          float64
+		expectedLen int
+	}{
+		{"Burst spawns particles", 5, 0, 5},
+		{"Burst cannot exceed maximum number of particles", 10, 0, 10},
+		{"Particles die when their lifetime is over", 0, 1, 0},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			em.Burst(tt.burst)
+			em.Update(tt.dt)
+			if em.Len() != tt.expectedLen {
+				t.Errorf("Expected %d living particles, got %d", tt.expectedLen, em.Len())
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	em := particle.NewEmitter(particle.Config{
+		Rate:         10,
+		MaxParticles: 100,
+		Lifetime:     [2]float64{5, 5},
+	}, pixel.ZV)
+	for i := 0; i < 4; i++ {
+		em.Update(0.25)
+	}
+	if em.Len() != 10 {
+		t.Errorf("Expected 10 particles spawned after a second, got %d", em.Len())
+	}
+}
+
+func TestUpdateDoesNotAllocate(t *testing.T) {
+	em := particle.NewEmitter(particle.Config{
+		Shape:        particle.ShapeCircle,
+		Radius:       10,
+		Rate:         1000,
+		MaxParticles: 500,
+		Lifetime:     [2]float64{0.1, 0.5},
+		Speed:        [2]float64{10, 50},
+		Angle:        [2]float64{0, 6.28},
+		Physics:      physic.Params{Gravity: 10, Drag: pixel.V(0.5, 0.5), TerminalVelocity: 20},
+	}, pixel.ZV)
+	allocs := testing.AllocsPerRun(100, func() {
+		em.Update(1.0 / 60)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations per update, got %f", allocs)
+	}
+}
+
+func TestCurve(t *testing.T) {
+	var testValues = []struct {
+		testName      string
+		curve         particle.Curve
+		t             float64
+		expectedValue float64
+	}{
+		{"Empty curve returns 1", particle.Curve{}, 0.5, 1},
+		{"Single point curve returns its value", particle.Curve{0.3}, 0.5, 0.3},
+		{"Curve interpolates between points", particle.Curve{1, 0}, 0.25, 0.75},
+		{"Curve returns last point at the end", particle.Curve{1, 0.5, 0}, 1, 0},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			if v := tt.curve.At(tt.t); v != tt.expectedValue {
+				t.Errorf("Expected %f, got %f", tt.expectedValue, v)
+			}
+		})
+	}
+}
+
+func TestDeserialize(t *testing.T) {
+	t.Run("Only valid JSON is supported", func(t *testing.T) {
+		r := bytes.NewReader([]byte(``))
+		if _, err := particle.Deserialize(r); err == nil {
+			t.Errorf("An invalid JSON file must return error")
+		}
+	})
+
+	t.Run("Only version 1 is supported", func(t *testing.T) {
+		r := bytes.NewReader([]byte(`{"version": "2", "emitters": {"dust": {}}}`))
+		if _, err := particle.Deserialize(r); err == nil {
+			t.Errorf("Invalid emitters data is loaded")
+		}
+	})
+
+	t.Run("Unknown shapes are not supported", func(t *testing.T) {
+		r := bytes.NewReader([]byte(`{"version": "1", "emitters": {"dust": {"shape": "star"}}}`))
+		_, err := particle.Deserialize(r)
+		if err == nil || err.Error() != fmt.Sprintf(particle.ErrorShapeNotSupported, "star") {
+			t.Errorf("Expected shape not supported error, got %v", err)
+		}
+	})
+
+	t.Run("Presets are loaded", func(t *testing.T) {
+		r := bytes.NewReader([]byte(`{
+			"version": "1",
+			"emitters": {
+				"sparks": {
+					"shape": "box",
+					"size": {"x": 10, "y": 4},
+					"max_particles": 50,
+					"lifetime": [0.5, 1],
+					"physics": {"gravity": 98},
+					"alpha": [1, 0]
+				}
+			}
+		}`))
+		presets, err := particle.Deserialize(r)
+		if err != nil {
+			t.Fatalf("Valid emitters data is not loaded: %s", err)
+		}
+		cfg := presets["sparks"]
+		if cfg.MaxParticles != 50 || cfg.Physics.Gravity != 98 || cfg.Size != pixel.V(10, 4) || len(cfg.Alpha) != 2 {
+			t.Errorf("Preset values not loaded properly, got %+v", cfg)
+		}
+	})
+}
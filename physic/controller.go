@@ -0,0 +1,151 @@
+package physic
+
+import (
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/bound"
+)
+
+// Resolver is implemented by collections of shapes which can resolve the movement of a shape against them,
+// like bound.SpatialHash and bound.World
+type Resolver interface {
+	Resolve(s bound.Shaper, delta pixel.Vec) bound.Solution
+}
+
+// ControllerParams is a set of values which define how a platformer character jumps
+type ControllerParams struct {
+	// JumpVelocity is the vertical velocity a jump starts with.
+	// Unlike velocities set with SetVelocity, it is not limited by MaxVelocity
+	JumpVelocity float64
+	// JumpCutoff multiplies the vertical velocity when jump is released while going up,
+	// so jump height depends on how long jump is held. 1 means jumps always reach the same height
+	JumpCutoff float64
+	// CoyoteTime is the time after leaving the ground in which the character can still jump
+	CoyoteTime float64
+	// JumpBuffer is the time a jump pressed while in the air is remembered, so it happens when landing
+	JumpBuffer float64
+}
+
+// Controller moves a platformer character against the level shapes, making it slide along walls,
+// and keeps track of its contacts with the ground, ceiling and walls
+type Controller struct {
+	*Physics
+	ControllerParams
+	dir         float64
+	jumpPressed bool
+	jumpHeld    bool
+	jumping     bool
+	coyoteTimer float64
+	jumpTimer   float64
+	grounded    bool
+	ceiling     bool
+	wall        float64
+}
+
+// NewController returns a new instance of controller
+func NewController(params Params, controllerParams ControllerParams) *Controller {
+	return &Controller{
+		Physics:          NewPhysics(params),
+		ControllerParams: controllerParams,
+	}
+}
+
+// Walk sets the horizontal direction the character moves to in the next updates.
+// Use DirectionLeft, DirectionRight, or 0 to stop
+func (c *Controller) Walk(dir float64) {
+	c.dir = dir
+}
+
+// PressJump makes the character jump in the next update if it can, or as soon as it can within the jump buffer
+func (c *Controller) PressJump() {
+	c.jumpPressed = true
+	c.jumpHeld = true
+	c.jumpTimer = c.JumpBuffer
+}
+
+// ReleaseJump cuts the current jump short if the character is still going up
+func (c *Controller) ReleaseJump() {
+	c.jumpHeld = false
+}
+
+// Update moves the character shape s against level after a dt time has passed,
+// and returns the movement done. The caller must move the character and s accordingly
+func (c *Controller) Update(dt float64, s bound.Shaper, level Resolver) pixel.Vec {
+	// Coyote time counts down from the first update started in the air
+	airborne := !c.grounded
+	if c.grounded {
+		c.coyoteTimer = c.CoyoteTime
+	}
+
+	if c.dir != 0 {
+		c.Accelerate(AxisX, c.dir, dt)
+	} else {
+		c.Decelerate(AxisX, dt)
+	}
+
+	if (c.jumpPressed || c.jumpTimer > 0) && (c.grounded || c.coyoteTimer > 0) {
+		c.setAxis(AxisY, c.JumpVelocity)
+		c.jumping = true
+		c.jumpTimer = 0
+		c.coyoteTimer = 0
+	}
+	if c.jumping && !c.jumpHeld {
+		if c.Velocity(AxisY) > 0 {
+			c.SetVelocity(AxisY, c.Velocity(AxisY)*c.JumpCutoff)
+		}
+		c.jumping = false
+	}
+
+	delta := c.Displacement(dt)
+	sol := level.Resolve(s, delta)
+	moved := delta
+	c.grounded, c.ceiling, c.wall = false, false, 0
+	if sol.CollisionAxis&bound.AxisX != 0 {
+		moved.X = sol.Distance.X
+		c.wall = DirectionRight
+		if delta.X < 0 {
+			c.wall = DirectionLeft
+		}
+		c.SetVelocity(AxisX, 0)
+	}
+	if sol.CollisionAxis&bound.AxisY != 0 {
+		moved.Y = sol.Distance.Y
+		// Slopes can stop shapes which do not move vertically, to keep them on their surface
+		if delta.Y <= 0 {
+			c.grounded = true
+			c.jumping = false
+		} else {
+			c.ceiling = true
+		}
+		c.SetVelocity(AxisY, 0)
+	}
+
+	c.jumpPressed = false
+	if airborne {
+		c.coyoteTimer = decrease(c.coyoteTimer, dt)
+	}
+	c.jumpTimer = decrease(c.jumpTimer, dt)
+	return moved
+}
+
+// Grounded returns true if the character stood on a shape after the last update
+func (c *Controller) Grounded() bool {
+	return c.grounded
+}
+
+// Ceiling returns true if the character hit a shape above it in the last update
+func (c *Controller) Ceiling() bool {
+	return c.ceiling
+}
+
+// Wall returns the direction of the wall the character touched in the last update, or 0 if there was none
+func (c *Controller) Wall() float64 {
+	return c.wall
+}
+
+// decrease returns timer after dt time has passed, never going below 0
+func decrease(timer, dt float64) float64 {
+	if timer <= dt {
+		return 0
+	}
+	return timer - dt
+}
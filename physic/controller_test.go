@@ -0,0 +1,143 @@
+package physic_test
+
+import (
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/bound"
+	"github.com/svera/quarter/physic"
+)
+
+type character struct {
+	*physic.Controller
+	box *bound.Box
+}
+
+func newCharacter(min pixel.Vec, jumpBuffer float64) *character {
+	return &character{
+		Controller: physic.NewController(
+			physic.Params{
+				MaxVelocity:  [2]float64{10, 100},
+				Acceleration: [2]float64{100, 0},
+				Gravity:      100,
+			},
+			physic.ControllerParams{
+				JumpVelocity: 50,
+				JumpCutoff:   0.5,
+				CoyoteTime:   0.2,
+				JumpBuffer:   jumpBuffer,
+			},
+		),
+		box: bound.NewBox(min, min.Add(pixel.V(10, 10))),
+	}
+}
+
+func (c *character) update(level physic.Resolver) {
+	c.box.Rect = c.box.Moved(c.Update(0.1, c.box, level))
+}
+
+func newLevel(shapes ...bound.Shaper) *bound.SpatialHash {
+	level := bound.NewSpatialHash(32)
+	for _, s := range shapes {
+		level.Insert(s)
+	}
+	return level
+}
+
+func TestController(t *testing.T) {
+	floor := bound.NewBox(pixel.V(-100, -10), pixel.V(100, 0))
+	level := newLevel(floor)
+	empty := newLevel()
+
+	t.Run("Character lands on the ground", func(t *testing.T) {
+		c := newCharacter(pixel.V(0, 5), 0)
+		for i := 0; i < 3; i++ {
+			c.update(level)
+		}
+		if !c.Grounded() || c.box.Min.Y != 0 || c.Velocity(physic.AxisY) != 0 {
+			t.Errorf("Expected character to stand on the ground, got %v", c.box)
+		}
+	})
+
+	t.Run("Character slides along walls", func(t *testing.T) {
+		c := newCharacter(pixel.V(9.5, 50), 0)
+		c.Walk(physic.DirectionRight)
+		c.update(newLevel(floor, bound.NewBox(pixel.V(20, 0), pixel.V(30, 100))))
+		if c.Wall() != physic.DirectionRight || c.box.Max.X != 20 || c.box.Min.Y != 49 {
+			t.Errorf("Expected character to touch the wall at its right while falling, got %v", c.box)
+		}
+	})
+
+	t.Run("Character hits the ceiling", func(t *testing.T) {
+		c := newCharacter(pixel.V(0, 0), 0)
+		lowCeiling := newLevel(floor, bound.NewBox(pixel.V(-100, 13), pixel.V(100, 20)))
+		c.update(lowCeiling)
+		c.PressJump()
+		c.update(lowCeiling)
+		if !c.Ceiling() || c.box.Max.Y != 13 || c.Velocity(physic.AxisY) != 0 {
+			t.Errorf("Expected character to stop at the ceiling, got %v", c.box)
+		}
+	})
+
+	var testValues = []struct {
+		testName          string
+		jumpBuffer        float64
+		updatesOnGround   int
+		updatesInAir      int
+		pressBeforeUpdate bool
+		expectedJump      bool
+	}{
+		{"Character jumps from the ground", 0, 1, 0, false, true},
+		{"Character jumps right after leaving the ground", 0, 1, 1, false, true},
+		{"Character jumps within coyote time after leaving the ground", 0, 1, 2, false, true},
+		{"Character does not jump after coyote time", 0, 1, 3, false, false},
+		{"Jump pressed before landing is buffered", 0.2, 1, 0, true, true},
+		{"Jump pressed before landing is ignored without buffer", 0, 1, 0, true, false},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			// Characters start falling half a pixel over the floor, so they land in the first update
+			c := newCharacter(pixel.V(0, 0.5), tt.jumpBuffer)
+			if tt.pressBeforeUpdate {
+				c.PressJump()
+				c.ReleaseJump()
+			}
+			for i := 0; i < tt.updatesOnGround; i++ {
+				c.update(level)
+			}
+			for i := 0; i < tt.updatesInAir; i++ {
+				c.update(empty)
+			}
+			if !tt.pressBeforeUpdate {
+				c.PressJump()
+			}
+			c.update(level)
+			if jumped := c.Velocity(physic.AxisY) > 0; jumped != tt.expectedJump {
+				t.Errorf("Expected jump to be %t, got vertical velocity %f", tt.expectedJump, c.Velocity(physic.AxisY))
+			}
+		})
+	}
+
+	t.Run("Releasing jump early makes it lower", func(t *testing.T) {
+		c := newCharacter(pixel.V(0, 0), 0)
+		c.update(level)
+		c.PressJump()
+		c.update(level)
+		c.ReleaseJump()
+		c.update(level)
+		if c.Velocity(physic.AxisY) != 10 {
+			t.Errorf("Expected vertical velocity 10, got %f", c.Velocity(physic.AxisY))
+		}
+	})
+
+	t.Run("Jump velocity is not limited by maximum velocity", func(t *testing.T) {
+		c := newCharacter(pixel.V(0, 0), 0)
+		c.MaxVelocity[physic.AxisY] = 20
+		c.update(level)
+		c.PressJump()
+		c.update(level)
+		if c.Velocity(physic.AxisY) != 40 {
+			t.Errorf("Expected vertical velocity 40, got %f", c.Velocity(physic.AxisY))
+		}
+	})
+}
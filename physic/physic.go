@@ -22,9 +22,21 @@ const (
 
 // Params is a set of values used to calculate velocity and displacement in both axes
 type Params struct {
+	// MaxVelocity is the maximum speed the item can reach by itself in every axis, like its run speed
 	MaxVelocity  [2]float64
 	Acceleration [2]float64
-	Gravity      float64
+	// Gravity pulls the item down
+	Gravity float64
+	// GravityVector pulls the item in any direction, and is used instead of Gravity when set
+	GravityVector pixel.Vec
+	// TerminalVelocity is the maximum speed the item can move at in the direction of gravity,
+	// no matter if it comes from gravity, forces or impulses. 0 means there is no limit
+	TerminalVelocity float64
+	// Drag is the fraction of velocity lost every second in every axis, like air resistance
+	Drag pixel.Vec
+	// Mass defines how much forces and impulses change the velocity of the item.
+	// Values not greater than 0 mean a mass of 1, the same as Body.Mass
+	Mass float64
 }
 
 // Physics controls movement of an element
@@ -32,7 +44,9 @@ type Physics struct {
 	// Speed of movement of the item in both X and Y axes
 	// negative values will move item to the left or down, positive ones to the right or up,
 	// depending on the axis
-	velocity [2]float64
+	velocity pixel.Vec
+	// force holds the forces applied since the last displacement
+	force pixel.Vec
 
 	Params
 }
@@ -46,43 +60,118 @@ func NewPhysics(params Params) *Physics {
 
 // Accelerate increases the speed of the item
 func (p *Physics) Accelerate(axis int, dir float64, dt float64) {
-	p.velocity[axis] += dir * p.Acceleration[axis] * dt
-	if math.Abs(p.velocity[axis]) > math.Abs(p.MaxVelocity[axis]) {
-		p.velocity[axis] = p.MaxVelocity[axis] * dir
+	p.AccelerateVec(axisVec(axis, dir), dt)
+}
+
+// AccelerateVec increases the speed of the item towards dir, in the axes dir is not 0,
+// up to its maximum velocity
+func (p *Physics) AccelerateVec(dir pixel.Vec, dt float64) {
+	v := p.velocity.Add(pixel.V(dir.X*p.Acceleration[AxisX], dir.Y*p.Acceleration[AxisY]).Scaled(dt))
+	if dir.X != 0 {
+		v.X = clamp(v.X, p.MaxVelocity[AxisX])
+	}
+	if dir.Y != 0 {
+		v.Y = clamp(v.Y, p.MaxVelocity[AxisY])
 	}
+	p.velocity = v
 }
 
 // Decelerate slow down velocity of the item in the passed axis
 func (p *Physics) Decelerate(axis int, dt float64) {
 	// We pick the minimum between velocity and acceleration
 	// to avoid decelerating too much and never stopping completely the object
-	val := math.Min(math.Abs(p.velocity[axis]), p.Acceleration[axis]*dt)
-	if p.velocity[axis] > 0 {
-		p.velocity[axis] -= val
-	}
-
-	if p.velocity[axis] < 0 {
-		p.velocity[axis] += val
-	}
+	velocity := p.Velocity(axis)
+	val := math.Min(math.Abs(velocity), p.Acceleration[axis]*dt)
+	p.setAxis(axis, velocity-math.Copysign(val, velocity))
 }
 
 // SetVelocity sets the speed of the item on the passed axis
 func (p *Physics) SetVelocity(axis int, value float64) {
-	if math.Abs(value) < math.Abs(p.MaxVelocity[axis]) {
-		p.velocity[axis] = value
-	} else {
-		p.velocity[axis] = p.MaxVelocity[axis]
-	}
+	p.setAxis(axis, clamp(value, p.MaxVelocity[axis]))
+}
+
+// SetVelocityVec sets the speed of the item, limited to its maximum velocity in every axis
+func (p *Physics) SetVelocityVec(v pixel.Vec) {
+	p.velocity = pixel.V(clamp(v.X, p.MaxVelocity[AxisX]), clamp(v.Y, p.MaxVelocity[AxisY]))
+}
+
+// ApplyImpulse changes the velocity of the item at once, like a hit or an explosion would do.
+// Impulses are not limited by the maximum velocity
+func (p *Physics) ApplyImpulse(impulse pixel.Vec) {
+	p.velocity = p.velocity.Add(impulse.Scaled(1 / p.mass()))
+}
+
+// ApplyForce pushes the item during the next displacement, like wind or a thruster would do.
+// Forces are not limited by the maximum velocity
+func (p *Physics) ApplyForce(force pixel.Vec) {
+	p.force = p.force.Add(force)
 }
 
 // Displacement returns the movement an item must do both in X and Y axes
 // after a dt time has passed
 func (p *Physics) Displacement(dt float64) pixel.Vec {
-	p.velocity[AxisY] -= p.Gravity * dt
-	return pixel.V(p.velocity[AxisX]*dt, p.velocity[AxisY]*dt)
+	gravity := p.gravity()
+	p.velocity = p.velocity.Add(gravity.Add(p.force.Scaled(1 / p.mass())).Scaled(dt))
+	p.force = pixel.ZV
+
+	p.velocity.X -= p.velocity.X * math.Min(p.Drag.X*dt, 1)
+	p.velocity.Y -= p.velocity.Y * math.Min(p.Drag.Y*dt, 1)
+
+	if p.TerminalVelocity > 0 && gravity != pixel.ZV {
+		down := gravity.Unit()
+		if speed := p.velocity.Dot(down); speed > p.TerminalVelocity {
+			p.velocity = p.velocity.Sub(down.Scaled(speed - p.TerminalVelocity))
+		}
+	}
+	return p.velocity.Scaled(dt)
 }
 
 // Velocity returns object speed in the passed axis
 func (p *Physics) Velocity(axis int) float64 {
-	return p.velocity[axis]
+	if axis == AxisX {
+		return p.velocity.X
+	}
+	return p.velocity.Y
+}
+
+// VelocityVec returns object speed in both axes
+func (p *Physics) VelocityVec() pixel.Vec {
+	return p.velocity
+}
+
+func (p *Physics) setAxis(axis int, value float64) {
+	if axis == AxisX {
+		p.velocity.X = value
+		return
+	}
+	p.velocity.Y = value
+}
+
+func (p *Physics) gravity() pixel.Vec {
+	if p.GravityVector != pixel.ZV {
+		return p.GravityVector
+	}
+	return pixel.V(0, -p.Gravity)
+}
+
+func (p *Physics) mass() float64 {
+	if p.Mass <= 0 {
+		return 1
+	}
+	return p.Mass
+}
+
+func axisVec(axis int, value float64) pixel.Vec {
+	if axis == AxisX {
+		return pixel.V(value, 0)
+	}
+	return pixel.V(0, value)
+}
+
+// clamp limits value to max in both directions, keeping its sign
+func clamp(value, max float64) float64 {
+	if math.Abs(value) > math.Abs(max) {
+		return math.Copysign(math.Abs(max), value)
+	}
+	return value
 }
@@ -101,6 +101,8 @@ func TestSetVelocity(t *testing.T) {
 		{"Velocity X greater than max", physic.AxisX, 15, 10},
 		{"Velocity Y lower than max", physic.AxisX, 5, 5},
 		{"Velocity Y greater than max", physic.AxisX, 15, 10},
+		{"Negative velocity X greater than max", physic.AxisX, -15, -10},
+		{"Negative velocity Y greater than max", physic.AxisY, -15, -10},
 	}
 
 	phys := physic.NewPhysics(physic.Params{
@@ -115,3 +117,96 @@ func TestSetVelocity(t *testing.T) {
 		})
 	}
 }
+
+func TestVectorPhysics(t *testing.T) {
+	var testValues = []struct {
+		testName         string
+		params           physic.Params
+		apply            func(p *physic.Physics)
+		expectedVelocity pixel.Vec
+	}{
+		{
+			"Gravity pulls in any direction",
+			physic.Params{GravityVector: pixel.V(4, 2)},
+			func(p *physic.Physics) {},
+			pixel.V(2, 1),
+		},
+		{
+			"Gravity vector is used instead of gravity",
+			physic.Params{Gravity: 10, GravityVector: pixel.V(0, 2)},
+			func(p *physic.Physics) {},
+			pixel.V(0, 1),
+		},
+		{
+			"Falling speed is limited by terminal velocity",
+			physic.Params{Gravity: 100, TerminalVelocity: 20},
+			func(p *physic.Physics) {},
+			pixel.V(0, -20),
+		},
+		{
+			"Terminal velocity limits impulses along gravity too",
+			physic.Params{Gravity: 10, TerminalVelocity: 20},
+			func(p *physic.Physics) { p.ApplyImpulse(pixel.V(0, -50)) },
+			pixel.V(0, -20),
+		},
+		{
+			"Terminal velocity does not limit other axes",
+			physic.Params{Gravity: 100, TerminalVelocity: 20},
+			func(p *physic.Physics) { p.ApplyImpulse(pixel.V(30, 0)) },
+			pixel.V(30, -20),
+		},
+		{
+			"Drag slows down every axis on its own",
+			physic.Params{Drag: pixel.V(1, 0)},
+			func(p *physic.Physics) { p.ApplyImpulse(pixel.V(10, 10)) },
+			pixel.V(5, 10),
+		},
+		{
+			"Impulses depend on mass and are not limited by max velocity",
+			physic.Params{MaxVelocity: [2]float64{1, 1}, Mass: 2},
+			func(p *physic.Physics) { p.ApplyImpulse(pixel.V(-10, 4)) },
+			pixel.V(-5, 2),
+		},
+		{
+			"Forces depend on mass and are applied over time",
+			physic.Params{Mass: 2},
+			func(p *physic.Physics) { p.ApplyForce(pixel.V(8, 0)); p.ApplyForce(pixel.V(0, -4)) },
+			pixel.V(2, -1),
+		},
+		{
+			"Acceleration keeps the sign of velocity when clamping it",
+			physic.Params{MaxVelocity: [2]float64{10, 10}, Acceleration: [2]float64{10, 10}},
+			func(p *physic.Physics) { p.ApplyImpulse(pixel.V(-20, 0)); p.AccelerateVec(pixel.V(1, 0), 0.5) },
+			pixel.V(-10, 0),
+		},
+		{
+			"Velocity is limited to max velocity in every axis",
+			physic.Params{MaxVelocity: [2]float64{10, 5}},
+			func(p *physic.Physics) { p.SetVelocityVec(pixel.V(-20, 20)) },
+			pixel.V(-10, 5),
+		},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			phys := physic.NewPhysics(tt.params)
+			tt.apply(phys)
+			dis := phys.Displacement(0.5)
+			if phys.VelocityVec() != tt.expectedVelocity {
+				t.Errorf("Expected velocity %v, got %v", tt.expectedVelocity, phys.VelocityVec())
+			}
+			if dis != tt.expectedVelocity.Scaled(0.5) {
+				t.Errorf("Expected displacement %v, got %v", tt.expectedVelocity.Scaled(0.5), dis)
+			}
+		})
+	}
+
+	t.Run("Forces are only applied once", func(t *testing.T) {
+		phys := physic.NewPhysics(physic.Params{})
+		phys.ApplyForce(pixel.V(2, 0))
+		phys.Displacement(1)
+		phys.Displacement(1)
+		if phys.VelocityVec() != pixel.V(2, 0) {
+			t.Errorf("Expected velocity %v, got %v", pixel.V(2, 0), phys.VelocityVec())
+		}
+	})
+}
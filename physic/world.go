@@ -0,0 +1,248 @@
+package physic
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/bound"
+)
+
+// Body types
+const (
+	// Static bodies never move, like walls and bricks
+	Static = iota
+	// Kinematic bodies move at their velocity, pushing dynamic bodies away, like paddles.
+	// They only stop when the bodies pushed are blocked by others
+	Kinematic
+	// Dynamic bodies are pulled by gravity and bounce off other bodies, like balls
+	Dynamic
+)
+
+// maxIterations is the maximum number of impacts resolved for a dynamic body in a single step
+const maxIterations = 4
+
+// DefaultMaxSteps is the maximum number of steps simulated in a single update by new worlds
+const DefaultMaxSteps = 8
+
+// Body is a shape moved by a World
+type Body struct {
+	Shape    bound.Shape
+	Type     int
+	Velocity pixel.Vec
+	// Mass of dynamic bodies, which defines how much they are pushed when hitting other dynamic bodies.
+	// Values not greater than 0 mean a mass of 1, the same as Params.Mass
+	Mass float64
+	// Friction slows down bodies sliding over others, from 0 (no friction) to 1
+	Friction float64
+	// Restitution defines how much bodies bounce, from 0 (no bounce) to 1 (no energy lost)
+	Restitution float64
+}
+
+// NewBody returns a new instance of body with a mass of 1
+func NewBody(s bound.Shape, bodyType int) *Body {
+	return &Body{
+		Shape: s,
+		Type:  bodyType,
+		Mass:  1,
+	}
+}
+
+// inverseMass returns 0 for bodies which can not be pushed
+func (b *Body) inverseMass() float64 {
+	if b.Type != Dynamic {
+		return 0
+	}
+	if b.Mass <= 0 {
+		return 1
+	}
+	return 1 / b.Mass
+}
+
+// World moves its bodies in fixed steps, making dynamic ones bounce off the others
+type World struct {
+	Gravity pixel.Vec
+	// Step is the time simulated in every step
+	Step float64
+	// MaxSteps is the maximum number of steps simulated in a single update, so a long frame does not make
+	// the next ones longer trying to catch up. The time which does not fit is dropped. 0 means no limit
+	MaxSteps int
+	// OnCollision, if set, is called every time dynamic body a hits b, with normal pointing from b to a
+	OnCollision func(a, b *Body, normal pixel.Vec)
+	bodies      []*Body
+	shapes      map[bound.Shaper]*Body
+	accumulator float64
+}
+
+// NewWorld returns a new empty World instance
+func NewWorld(gravity pixel.Vec, step float64) *World {
+	return &World{
+		Gravity:  gravity,
+		Step:     step,
+		MaxSteps: DefaultMaxSteps,
+		shapes:   make(map[bound.Shaper]*Body),
+	}
+}
+
+// Add adds b to the world
+func (w *World) Add(b *Body) {
+	if _, ok := w.shapes[b.Shape]; ok {
+		return
+	}
+	w.bodies = append(w.bodies, b)
+	w.shapes[b.Shape] = b
+}
+
+// Remove takes b out of the world
+func (w *World) Remove(b *Body) {
+	for i := range w.bodies {
+		if w.bodies[i] == b {
+			w.bodies = append(w.bodies[:i], w.bodies[i+1:]...)
+			delete(w.shapes, b.Shape)
+			return
+		}
+	}
+}
+
+// Bodies returns the bodies in the world
+func (w *World) Bodies() []*Body {
+	return w.bodies
+}
+
+// Update simulates as many steps as fit in dt plus the time left over from previous updates,
+// up to MaxSteps, and returns the number of steps done
+func (w *World) Update(dt float64) int {
+	if w.Step <= 0 {
+		return 0
+	}
+	w.accumulator += dt
+	steps := 0
+	for w.accumulator >= w.Step {
+		if w.MaxSteps > 0 && steps >= w.MaxSteps {
+			w.accumulator = math.Mod(w.accumulator, w.Step)
+			break
+		}
+		w.step(w.Step)
+		w.accumulator -= w.Step
+		steps++
+	}
+	return steps
+}
+
+func (w *World) step(h float64) {
+	for _, b := range w.bodies {
+		if b.Type == Kinematic {
+			w.moveKinematic(b, b.Velocity.Scaled(h))
+		}
+	}
+	for _, b := range w.bodies {
+		if b.Type == Dynamic {
+			b.Velocity = b.Velocity.Add(w.Gravity.Scaled(h))
+			w.moveDynamic(b, h)
+		}
+	}
+}
+
+// push holds a dynamic body hit by a kinematic one, and the fraction of the kinematic movement done before
+type push struct {
+	body   *Body
+	toi    float64
+	normal pixel.Vec
+}
+
+// moveKinematic moves k delta, pushing the dynamic bodies in its way.
+// k stops as soon as any of them is blocked by the other bodies, so they are never pushed into them
+func (w *World) moveKinematic(k *Body, delta pixel.Vec) {
+	if delta == pixel.ZV {
+		return
+	}
+	fraction := 1.0
+	var pushes []push
+	for _, b := range w.bodies {
+		if b.Type != Dynamic {
+			continue
+		}
+		sol := bound.Sweep(k.Shape, delta, b.Shape)
+		if sol.Object == nil {
+			continue
+		}
+		pushes = append(pushes, push{body: b, toi: sol.TOI, normal: sol.Normal.Scaled(-1)})
+		blocked := bound.Sweep(b.Shape, delta.Scaled(1-sol.TOI), w.others(b, k)...)
+		if blocked.Object != nil {
+			fraction = math.Min(fraction, sol.TOI+blocked.TOI*(1-sol.TOI))
+		}
+	}
+	for _, p := range pushes {
+		if p.toi < fraction {
+			bound.Translate(p.body.Shape, delta.Scaled(fraction-p.toi))
+		}
+		w.collide(p.body, k, p.normal)
+	}
+	bound.Translate(k.Shape, delta.Scaled(fraction))
+}
+
+// others returns the shapes of all bodies but the passed ones
+func (w *World) others(skip ...*Body) []bound.Shaper {
+	others := make([]bound.Shaper, 0, len(w.bodies))
+	for _, b := range w.bodies {
+		if !contains(skip, b) {
+			others = append(others, b.Shape)
+		}
+	}
+	return others
+}
+
+func contains(bodies []*Body, b *Body) bool {
+	for _, body := range bodies {
+		if body == b {
+			return true
+		}
+	}
+	return false
+}
+
+// moveDynamic moves b along its velocity during h time, bouncing off the bodies it hits
+func (w *World) moveDynamic(b *Body, h float64) {
+	others := w.others(b)
+
+	for i := 0; i < maxIterations && h > 0; i++ {
+		sol := bound.Sweep(b.Shape, b.Velocity.Scaled(h), others...)
+		bound.Translate(b.Shape, sol.Distance)
+		if sol.Object == nil {
+			return
+		}
+		w.collide(b, w.shapes[sol.Object], sol.Normal)
+		h *= 1 - sol.TOI
+	}
+}
+
+// collide applies the impulses of dynamic body a hitting b, with normal pointing from b to a
+func (w *World) collide(a, b *Body, normal pixel.Vec) {
+	relative := a.Velocity.Sub(b.Velocity)
+	vn := relative.Dot(normal)
+	if vn >= 0 {
+		return
+	}
+	invA, invB := a.inverseMass(), b.inverseMass()
+	if invA+invB == 0 {
+		return
+	}
+
+	restitution := math.Max(a.Restitution, b.Restitution)
+	j := -(1 + restitution) * vn / (invA + invB)
+	a.Velocity = a.Velocity.Add(normal.Scaled(j * invA))
+	b.Velocity = b.Velocity.Sub(normal.Scaled(j * invB))
+
+	// Friction works against sliding, and can not be stronger than the impact
+	tangent := relative.Sub(normal.Scaled(vn))
+	if tangent.Len() > 0 {
+		tangent = tangent.Unit()
+		friction := math.Sqrt(a.Friction * b.Friction)
+		jt := pixel.Clamp(-relative.Dot(tangent)/(invA+invB), -friction*j, friction*j)
+		a.Velocity = a.Velocity.Add(tangent.Scaled(jt * invA))
+		b.Velocity = b.Velocity.Sub(tangent.Scaled(jt * invB))
+	}
+
+	if w.OnCollision != nil {
+		w.OnCollision(a, b, normal)
+	}
+}
@@ -0,0 +1,162 @@
+package physic_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/bound"
+	"github.com/svera/quarter/physic"
+)
+
+func TestWorldSteps(t *testing.T) {
+	ball := physic.NewBody(bound.NewCircle(0, 0, 1), physic.Dynamic)
+	world := physic.NewWorld(pixel.V(0, -8), 0.25)
+	world.Add(ball)
+
+	t.Run("Dynamic bodies fall with gravity", func(t *testing.T) {
+		if steps := world.Update(1); steps != 4 {
+			t.Errorf("Expected 4 steps, got %d", steps)
+		}
+		if ball.Velocity != pixel.V(0, -8) || ball.Shape.(*bound.Circle).Center != pixel.V(0, -5) {
+			t.Errorf("Expected ball at %v with velocity %v, got %v", pixel.V(0, -5), pixel.V(0, -8), ball.Shape)
+		}
+	})
+
+	t.Run("Time left over is kept for next updates", func(t *testing.T) {
+		if steps := world.Update(0.125); steps != 0 {
+			t.Errorf("Expected no steps, got %d", steps)
+		}
+		if steps := world.Update(0.125); steps != 1 {
+			t.Errorf("Expected 1 step, got %d", steps)
+		}
+	})
+
+	t.Run("Time beyond the maximum number of steps is dropped", func(t *testing.T) {
+		world.MaxSteps = 2
+		if steps := world.Update(1.125); steps != 2 {
+			t.Errorf("Expected 2 steps, got %d", steps)
+		}
+		if steps := world.Update(0.125); steps != 1 {
+			t.Errorf("Expected only the time left over by a step to be kept, got %d steps", steps)
+		}
+	})
+}
+
+func TestWorldCollisions(t *testing.T) {
+	var testValues = []struct {
+		testName         string
+		restitution      float64
+		expectedVelocity pixel.Vec
+		expectedCenter   pixel.Vec
+	}{
+		{"Balls bounce", 1, pixel.V(0, 10), pixel.V(0, 2)},
+		{"Balls without restitution stop", 0, pixel.ZV, pixel.V(0, 1)},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			ball := physic.NewBody(bound.NewCircle(0, 5, 1), physic.Dynamic)
+			ball.Velocity = pixel.V(0, -10)
+			ball.Restitution = tt.restitution
+			floor := physic.NewBody(bound.NewBox(pixel.V(-10, -10), pixel.V(10, 0)), physic.Static)
+			world := physic.NewWorld(pixel.ZV, 0.25)
+			world.Add(ball)
+			world.Add(floor)
+			var hits int
+			world.OnCollision = func(a, b *physic.Body, normal pixel.Vec) {
+				if a == ball && b == floor && normal == pixel.V(0, 1) {
+					hits++
+				}
+			}
+			world.Update(0.5)
+			center := ball.Shape.(*bound.Circle).Center
+			if ball.Velocity.Sub(tt.expectedVelocity).Len() > 1e-9 || center.Sub(tt.expectedCenter).Len() > 1e-9 {
+				t.Errorf("Expected ball at %v with velocity %v, got %v with velocity %v", tt.expectedCenter, tt.expectedVelocity, center, ball.Velocity)
+			}
+			if hits != 1 {
+				t.Errorf("Expected collision callback to be called once, got %d", hits)
+			}
+			if floor.Velocity != pixel.ZV || floor.Shape.(*bound.Box).Rect != pixel.R(-10, -10, 10, 0) {
+				t.Errorf("Static bodies must not move")
+			}
+		})
+	}
+
+	t.Run("Friction slows down bodies sliding over others", func(t *testing.T) {
+		box := physic.NewBody(bound.NewBox(pixel.V(0, 0), pixel.V(2, 2)), physic.Dynamic)
+		box.Velocity = pixel.V(10, 0)
+		box.Friction = 1
+		floor := physic.NewBody(bound.NewBox(pixel.V(-100, -10), pixel.V(100, 0)), physic.Static)
+		floor.Friction = 1
+		world := physic.NewWorld(pixel.V(0, -10), 0.25)
+		world.Add(box)
+		world.Add(floor)
+		world.Update(1)
+		if box.Velocity != pixel.ZV || box.Shape.(*bound.Box).Min.Y != 0 {
+			t.Errorf("Expected box to stop over the floor, got %v with velocity %v", box.Shape, box.Velocity)
+		}
+	})
+
+	t.Run("Kinematic bodies push dynamic bodies", func(t *testing.T) {
+		paddle := physic.NewBody(bound.NewBox(pixel.V(0, 0), pixel.V(10, 2)), physic.Kinematic)
+		paddle.Velocity = pixel.V(0, 10)
+		ball := physic.NewBody(bound.NewCircle(5, 3, 1), physic.Dynamic)
+		world := physic.NewWorld(pixel.ZV, 0.25)
+		world.Add(paddle)
+		world.Add(ball)
+		world.Update(0.25)
+		if paddle.Shape.(*bound.Box).Min.Y != 2.5 || paddle.Velocity != pixel.V(0, 10) {
+			t.Errorf("Expected paddle to move at its velocity, got %v", paddle.Shape)
+		}
+		if ball.Velocity != pixel.V(0, 10) || ball.Shape.(*bound.Circle).Center != pixel.V(5, 8) {
+			t.Errorf("Expected ball to be pushed by the paddle, got %v with velocity %v", ball.Shape, ball.Velocity)
+		}
+	})
+
+	t.Run("Kinematic bodies stop when pushed bodies are blocked", func(t *testing.T) {
+		paddle := physic.NewBody(bound.NewBox(pixel.V(0, 0), pixel.V(2, 10)), physic.Kinematic)
+		paddle.Velocity = pixel.V(20, 0)
+		ball := physic.NewBody(bound.NewCircle(4, 5, 1), physic.Dynamic)
+		wall := physic.NewBody(bound.NewBox(pixel.V(6, 0), pixel.V(8, 10)), physic.Static)
+		world := physic.NewWorld(pixel.ZV, 0.25)
+		world.Add(paddle)
+		world.Add(ball)
+		world.Add(wall)
+		world.Update(0.5)
+		if math.Abs(paddle.Shape.(*bound.Box).Max.X-4) > 1e-9 {
+			t.Errorf("Expected paddle to stop when the ball hits the wall, got %v", paddle.Shape)
+		}
+		if center := ball.Shape.(*bound.Circle).Center; math.Abs(center.X-5) > 1e-9 || ball.Shape.Collides(wall.Shape) {
+			t.Errorf("Expected ball to be pushed against the wall without going into it, got %v", ball.Shape)
+		}
+	})
+}
+
+func TestWorldImpulses(t *testing.T) {
+	var testValues = []struct {
+		testName   string
+		massB      float64
+		expectedVA float64
+		expectedVB float64
+	}{
+		{"Bodies with the same mass exchange their velocities", 1, 0, 10},
+		{"Heavier bodies are pushed less", 3, -5, 5},
+		{"Bodies without mass have a mass of 1", 0, 0, 10},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			a := physic.NewBody(bound.NewCircle(0, 0, 1), physic.Dynamic)
+			a.Velocity = pixel.V(10, 0)
+			a.Restitution = 1
+			b := physic.NewBody(bound.NewCircle(5, 0, 1), physic.Dynamic)
+			b.Mass = tt.massB
+			world := physic.NewWorld(pixel.ZV, 0.25)
+			world.Add(a)
+			world.Add(b)
+			world.Update(0.5)
+			if math.Abs(a.Velocity.X-tt.expectedVA) > 1e-9 || math.Abs(b.Velocity.X-tt.expectedVB) > 1e-9 {
+				t.Errorf("Expected velocities %f and %f, got %f and %f", tt.expectedVA, tt.expectedVB, a.Velocity.X, b.Velocity.X)
+			}
+		})
+	}
+}
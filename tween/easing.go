@@ -0,0 +1,137 @@
+package tween
+
+import "math"
+
+// Easing maps the linear progress of a tween, from 0 to 1, to the progress that has to be applied to the animated value.
+// See https://easings.net for a visual reference of the easing functions included in this package
+type Easing func(t float64) float64
+
+const (
+	backOvershoot  = 1.70158
+	elasticPeriod  = 2 * math.Pi / 3
+	elasticPeriod2 = 2 * math.Pi / 4.5
+	bounceN        = 7.5625
+	bounceD        = 2.75
+)
+
+// Linear does not apply any easing
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad accelerates from zero velocity
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad decelerates to zero velocity
+func OutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// InOutQuad accelerates until halfway, then decelerates
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// InCubic accelerates from zero velocity
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+// OutCubic decelerates to zero velocity
+func OutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+// InOutCubic accelerates until halfway, then decelerates
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// InBack goes slightly backwards before moving to the target value
+func InBack(t float64) float64 {
+	return t * t * ((backOvershoot+1)*t - backOvershoot)
+}
+
+// OutBack overshoots the target value and then goes back to it
+func OutBack(t float64) float64 {
+	t--
+	return t*t*((backOvershoot+1)*t+backOvershoot) + 1
+}
+
+// InOutBack goes slightly backwards at the beginning and overshoots at the end
+func InOutBack(t float64) float64 {
+	s := backOvershoot * 1.525
+	if t < 0.5 {
+		t *= 2
+		return t * t * ((s+1)*t - s) / 2
+	}
+	t = 2*t - 2
+	return (t*t*((s+1)*t+s) + 2) / 2
+}
+
+// InElastic oscillates with increasing amplitude before moving to the target value
+func InElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((10*t-10.75)*elasticPeriod)
+}
+
+// OutElastic overshoots the target value and oscillates around it with decreasing amplitude
+func OutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((10*t-0.75)*elasticPeriod) + 1
+}
+
+// InOutElastic combines InElastic and OutElastic
+func InOutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	if t < 0.5 {
+		return -math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*elasticPeriod2) / 2
+	}
+	return math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*elasticPeriod2)/2 + 1
+}
+
+// InBounce bounces with increasing height before moving to the target value
+func InBounce(t float64) float64 {
+	return 1 - OutBounce(1-t)
+}
+
+// OutBounce bounces against the target value like a falling ball
+func OutBounce(t float64) float64 {
+	switch {
+	case t < 1/bounceD:
+		return bounceN * t * t
+	case t < 2/bounceD:
+		t -= 1.5 / bounceD
+		return bounceN*t*t + 0.75
+	case t < 2.5/bounceD:
+		t -= 2.25 / bounceD
+		return bounceN*t*t + 0.9375
+	default:
+		t -= 2.625 / bounceD
+		return bounceN*t*t + 0.984375
+	}
+}
+
+// InOutBounce combines InBounce and OutBounce
+func InOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - OutBounce(1-2*t)) / 2
+	}
+	return (1 + OutBounce(2*t-1)) / 2
+}
@@ -0,0 +1,67 @@
+package tween
+
+// Tweener is the minimum contract required for anything that can be updated by a Manager
+type Tweener interface {
+	Update(dt float64)
+	Done() bool
+}
+
+// Manager updates many tweens at once, forgetting them when they are over
+type Manager struct {
+	tweens []Tweener
+	// pending holds the tweens added while updating, like the ones chained from completion callbacks
+	pending  []Tweener
+	updating bool
+}
+
+// NewManager returns a new Manager instance
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Add adds the passed tweens to the manager. Tweens added while updating are updated from the next call on
+func (m *Manager) Add(tweens ...Tweener) {
+	if m.updating {
+		m.pending = append(m.pending, tweens...)
+		return
+	}
+	m.tweens = append(m.tweens, tweens...)
+}
+
+// Update advances all managed tweens dt seconds and removes the ones that are over
+func (m *Manager) Update(dt float64) {
+	m.updating = true
+	active := m.tweens[:0]
+	for _, t := range m.tweens {
+		t.Update(dt)
+		if !t.Done() {
+			active = append(active, t)
+		}
+	}
+	m.updating = false
+	for i := len(active); i < len(m.tweens); i++ {
+		m.tweens[i] = nil
+	}
+	m.tweens = append(active, m.pending...)
+	for i := range m.pending {
+		m.pending[i] = nil
+	}
+	m.pending = m.pending[:0]
+}
+
+// Len returns the number of tweens being managed
+func (m *Manager) Len() int {
+	return len(m.tweens) + len(m.pending)
+}
+
+// Clear removes all tweens from the manager
+func (m *Manager) Clear() {
+	for i := range m.tweens {
+		m.tweens[i] = nil
+	}
+	m.tweens = m.tweens[:0]
+	for i := range m.pending {
+		m.pending[i] = nil
+	}
+	m.pending = m.pending[:0]
+}
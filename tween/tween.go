@@ -0,0 +1,162 @@
+// Package tween interpolates values over time using easing functions.
+package tween
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+)
+
+// Tween modes
+const (
+	// Once plays the tween from start to end a single time
+	Once = iota
+	// Loop plays the tween from start to end, jumping back to the start every time it ends
+	Loop
+	// Yoyo plays the tween from start to end and back again
+	Yoyo
+)
+
+// Tween holds the progress of an interpolation over time
+type Tween struct {
+	Duration float64
+	Easing   Easing
+	Mode     int
+	// Loops is the number of cycles to play in Loop and Yoyo modes before the tween is over.
+	// 0 means playing forever
+	Loops int
+	// OnComplete is called once when the tween is over
+	OnComplete func()
+	elapsed    float64
+	done       bool
+}
+
+// New returns a new Tween instance that lasts duration seconds.
+// If easing is nil, Linear is used
+func New(duration float64, easing Easing) *Tween {
+	if easing == nil {
+		easing = Linear
+	}
+	return &Tween{
+		Duration: duration,
+		Easing:   easing,
+		Mode:     Once,
+	}
+}
+
+// Update advances the tween dt seconds
+func (t *Tween) Update(dt float64) {
+	if t.done {
+		return
+	}
+	t.elapsed += dt
+	if t.Duration <= 0 || (t.Mode == Once && t.elapsed >= t.Duration) ||
+		(t.Mode != Once && t.Loops > 0 && t.elapsed >= float64(t.Loops)*t.period()) {
+		t.done = true
+		if t.OnComplete != nil {
+			t.OnComplete()
+		}
+	}
+}
+
+// Progress returns the eased progress of the tween, being 0 its start and 1 its end.
+// Some easing functions may return values out of that range
+func (t *Tween) Progress() float64 {
+	return t.Easing(t.linearProgress())
+}
+
+// linearProgress handles tweens without duration as already over, even before their first update
+func (t *Tween) linearProgress() float64 {
+	if t.done || t.Duration <= 0 {
+		if t.Mode == Yoyo {
+			return 0
+		}
+		return 1
+	}
+	local := math.Mod(t.elapsed, t.period())
+	if t.Mode == Yoyo && local > t.Duration {
+		local = t.period() - local
+	}
+	return local / t.Duration
+}
+
+func (t *Tween) period() float64 {
+	if t.Mode == Yoyo {
+		return t.Duration * 2
+	}
+	return t.Duration
+}
+
+// Done returns whether the tween is over or not
+func (t *Tween) Done() bool {
+	return t.done
+}
+
+// Reset sets the tween back to its start
+func (t *Tween) Reset() {
+	t.elapsed = 0
+	t.done = false
+}
+
+// Float interpolates a float64 value
+type Float struct {
+	*Tween
+	From float64
+	To   float64
+}
+
+// NewFloat returns a new Float instance
+func NewFloat(from, to, duration float64, easing Easing) *Float {
+	return &Float{
+		Tween: New(duration, easing),
+		From:  from,
+		To:    to,
+	}
+}
+
+// Value returns the current interpolated value
+func (f *Float) Value() float64 {
+	return f.From + (f.To-f.From)*f.Progress()
+}
+
+// Vec interpolates a pixel.Vec value
+type Vec struct {
+	*Tween
+	From pixel.Vec
+	To   pixel.Vec
+}
+
+// NewVec returns a new Vec instance
+func NewVec(from, to pixel.Vec, duration float64, easing Easing) *Vec {
+	return &Vec{
+		Tween: New(duration, easing),
+		From:  from,
+		To:    to,
+	}
+}
+
+// Value returns the current interpolated value
+func (v *Vec) Value() pixel.Vec {
+	return pixel.Lerp(v.From, v.To, v.Progress())
+}
+
+// Color interpolates a pixel.RGBA value
+type Color struct {
+	*Tween
+	From pixel.RGBA
+	To   pixel.RGBA
+}
+
+// NewColor returns a new Color instance
+func NewColor(from, to pixel.RGBA, duration float64, easing Easing) *Color {
+	return &Color{
+		Tween: New(duration, easing),
+		From:  from,
+		To:    to,
+	}
+}
+
+// Value returns the current interpolated value
+func (c *Color) Value() pixel.RGBA {
+	return c.From.Add(c.To.Sub(c.From).Scaled(c.Progress()))
+}
@@ -0,0 +1,151 @@
+package tween_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/svera/quarter/tween"
+)
+
+const tolerance = 1e-9
+
+func TestEasingEndpoints(t *testing.T) {
+	var testValues = []struct {
+		testName string
+		easing   tween.Easing
+	}{
+		{"Linear", tween.Linear},
+		{"InQuad", tween.InQuad},
+		{"OutQuad", tween.OutQuad},
+		{"InOutQuad", tween.InOutQuad},
+		{"InCubic", tween.InCubic},
+		{"OutCubic", tween.OutCubic},
+		{"InOutCubic", tween.InOutCubic},
+		{"InBack", tween.InBack},
+		{"OutBack", tween.OutBack},
+		{"InOutBack", tween.InOutBack},
+		{"InElastic", tween.InElastic},
+		{"OutElastic", tween.OutElastic},
+		{"InOutElastic", tween.InOutElastic},
+		{"InBounce", tween.InBounce},
+		{"OutBounce", tween.OutBounce},
+		{"InOutBounce", tween.InOutBounce},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			if v := tt.easing(0); math.Abs(v) > tolerance {
+				t.Errorf("Expected easing to start at 0, got %f", v)
+			}
+			if v := tt.easing(1); math.Abs(v-1) > tolerance {
+				t.Errorf("Expected easing to end at 1, got %f", v)
+			}
+		})
+	}
+}
+
+func TestFloat(t *testing.T) {
+	var testValues = []struct {
+		testName      string
+		mode          int
+		loops         int
+		dt            float64
+		expectedValue float64
+		expectedDone  bool
+	}{
+		{"Once mode in progress", tween.Once, 0, 0.5, 5, false},
+		{"Once mode over", tween.Once, 0, 1.5, 10, true},
+		{"Loop mode jumps back to start", tween.Loop, 0, 1.25, 2.5, false},
+		{"Loop mode over after loops are played", tween.Loop, 2, 2.5, 10, true},
+		{"Yoyo mode goes back", tween.Yoyo, 0, 1.25, 7.5, false},
+		{"Yoyo mode over after loops are played", tween.Yoyo, 1, 2.5, 0, true},
+	}
+	for _, tt := range testValues {
+		t.Run(tt.testName, func(t *testing.T) {
+			tw := tween.NewFloat(0, 10, 1, tween.Linear)
+			tw.Mode = tt.mode
+			tw.Loops = tt.loops
+			tw.Update(tt.dt)
+			if math.Abs(tw.Value()-tt.expectedValue) > tolerance {
+				t.Errorf("Expected value %f, got %f", tt.expectedValue, tw.Value())
+			}
+			if tw.Done() != tt.expectedDone {
+				t.Errorf("Expected done to be %t, got %t", tt.expectedDone, tw.Done())
+			}
+		})
+	}
+}
+
+func TestZeroDuration(t *testing.T) {
+	tw := tween.NewFloat(0, 10, 0, tween.Linear)
+	if tw.Value() != 10 {
+		t.Errorf("Expected tweens without duration to be at their end before updating, got %f", tw.Value())
+	}
+}
+
+func TestOnComplete(t *testing.T) {
+	calls := 0
+	tw := tween.NewVec(pixel.ZV, pixel.V(10, 20), 1, tween.OutQuad)
+	tw.OnComplete = func() { calls++ }
+	tw.Update(0.5)
+	tw.Update(0.6)
+	tw.Update(0.6)
+	if calls != 1 {
+		t.Errorf("Expected OnComplete to be called once, got %d calls", calls)
+	}
+	if tw.Value() != pixel.V(10, 20) {
+		t.Errorf("Expected final value %v, got %v", pixel.V(10, 20), tw.Value())
+	}
+}
+
+func TestColor(t *testing.T) {
+	tw := tween.NewColor(pixel.RGB(0, 0, 0), pixel.RGB(1, 0.5, 0), 2, nil)
+	tw.Update(1)
+	expected := pixel.RGBA{R: 0.5, G: 0.25, B: 0, A: 1}
+	if tw.Value() != expected {
+		t.Errorf("Expected color %v, got %v", expected, tw.Value())
+	}
+}
+
+func TestManager(t *testing.T) {
+	m := tween.NewManager()
+	short := tween.NewFloat(0, 1, 1, nil)
+	long := tween.NewFloat(0, 1, 2, nil)
+	m.Add(short, long)
+
+	m.Update(1.5)
+	if m.Len() != 1 {
+		t.Errorf("Expected finished tweens to be removed, got %d tweens", m.Len())
+	}
+	if !short.Done() || long.Done() {
+		t.Errorf("Expected all tweens to be updated with a single call")
+	}
+	m.Update(1)
+	if m.Len() != 0 {
+		t.Errorf("Expected no tweens left, got %d tweens", m.Len())
+	}
+}
+
+func TestManagerChaining(t *testing.T) {
+	m := tween.NewManager()
+	first := tween.NewFloat(0, 1, 1, nil)
+	second := tween.NewFloat(1, 0, 1, nil)
+	first.OnComplete = func() { m.Add(second) }
+	m.Add(first)
+
+	m.Update(1)
+	if m.Len() != 1 {
+		t.Fatalf("Expected tween added from OnComplete to be kept, got %d tweens", m.Len())
+	}
+	if second.Value() != 1 {
+		t.Errorf("Expected chained tween not to be updated in the call it was added, got %f", second.Value())
+	}
+	m.Update(0.5)
+	if second.Value() != 0.5 {
+		t.Errorf("Expected chained tween to be updated, got %f", second.Value())
+	}
+	m.Update(0.5)
+	if m.Len() != 0 {
+		t.Errorf("Expected no tweens left, got %d tweens", m.Len())
+	}
+}